@@ -0,0 +1,141 @@
+package calendar
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// date returns midnight of the given day in UTC
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// truncate drops the time of day and location of t, keeping its calendar date
+func truncate(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day())
+}
+
+// nthWeekday returns the nth weekday of the month, counting from 1
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+// lastWeekday returns the last weekday of the month
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := date(year, month+1, 0)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// observed returns the day a fixed-date holiday is observed by the Federal Reserve.
+// Holidays falling on a Sunday are observed on the following Monday, holidays falling on a Saturday are not observed.
+func observed(t time.Time) (time.Time, bool) {
+	switch t.Weekday() {
+	case time.Sunday:
+		return t.AddDate(0, 0, 1), true
+	case time.Saturday:
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// Holidays returns the observed Federal Reserve holidays of the year in date order
+func Holidays(year int) []time.Time {
+	fixed := []time.Time{
+		date(year, time.January, 1),   // New Year's Day
+		date(year, time.July, 4),      // Independence Day
+		date(year, time.November, 11), // Veterans Day
+		date(year, time.December, 25), // Christmas Day
+	}
+	if year >= 2022 {
+		fixed = append(fixed, date(year, time.June, 19)) // Juneteenth National Independence Day
+	}
+
+	holidays := []time.Time{
+		nthWeekday(year, time.January, time.Monday, 3),    // Birthday of Martin Luther King, Jr.
+		nthWeekday(year, time.February, time.Monday, 3),   // Washington's Birthday
+		lastWeekday(year, time.May, time.Monday),          // Memorial Day
+		nthWeekday(year, time.September, time.Monday, 1),  // Labor Day
+		nthWeekday(year, time.October, time.Monday, 2),    // Columbus Day
+		nthWeekday(year, time.November, time.Thursday, 4), // Thanksgiving Day
+	}
+	for _, holiday := range fixed {
+		if day, ok := observed(holiday); ok {
+			holidays = append(holidays, day)
+		}
+	}
+
+	slices.SortFunc(holidays, func(a, b time.Time) int { return a.Compare(b) })
+	return holidays
+}
+
+// holidayCache holds the observed holidays of every year looked up by IsHoliday
+var holidayCache = struct {
+	sync.Mutex
+	years map[int]map[time.Time]bool
+}{years: make(map[int]map[time.Time]bool)}
+
+// holidaySet returns the observed Federal Reserve holidays of the year, computing them once per year
+func holidaySet(year int) map[time.Time]bool {
+	holidayCache.Lock()
+	defer holidayCache.Unlock()
+
+	set, ok := holidayCache.years[year]
+	if !ok {
+		set = make(map[time.Time]bool)
+		for _, holiday := range Holidays(year) {
+			set[holiday] = true
+		}
+		holidayCache.years[year] = set
+	}
+
+	return set
+}
+
+// IsHoliday reports whether the date of t is an observed Federal Reserve holiday
+func IsHoliday(t time.Time) bool {
+	day := truncate(t)
+	return holidaySet(day.Year())[day]
+}
+
+// IsWeekend reports whether the date of t is a Saturday or a Sunday
+func IsWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// IsBankingDay reports whether the date of t is a Federal Reserve banking day
+func IsBankingDay(t time.Time) bool {
+	return !IsWeekend(t) && !IsHoliday(t)
+}
+
+// NextBankingDay returns t if its date is a banking day, otherwise the first banking day after it.
+// The time of day and location of t are kept.
+func NextBankingDay(t time.Time) time.Time {
+	for !IsBankingDay(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t
+}
+
+// AddBankingDays returns the date n banking days after t, or before t when n is negative.
+// The time of day and location of t are kept.
+func AddBankingDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if IsBankingDay(t) {
+			n--
+		}
+	}
+
+	return t
+}
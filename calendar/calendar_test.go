@@ -0,0 +1,86 @@
+package calendar
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHolidays(t *testing.T) {
+	tests := []struct {
+		year int
+		want []string
+	}{
+		{
+			// December 25 is a Saturday and is not observed, July 4 is a Sunday and is observed on Monday
+			year: 2021,
+			want: []string{"2021-01-01", "2021-01-18", "2021-02-15", "2021-05-31", "2021-07-05", "2021-09-06",
+				"2021-10-11", "2021-11-11", "2021-11-25"},
+		},
+		{
+			// First year of Juneteenth, January 1 is a Saturday, June 19 and December 25 are Sundays
+			year: 2022,
+			want: []string{"2022-01-17", "2022-02-21", "2022-05-30", "2022-06-20", "2022-07-04", "2022-09-05",
+				"2022-10-10", "2022-11-11", "2022-11-24", "2022-12-26"},
+		},
+		{
+			// January 1 is a Sunday, November 11 is a Saturday
+			year: 2023,
+			want: []string{"2023-01-02", "2023-01-16", "2023-02-20", "2023-05-29", "2023-06-19", "2023-07-04",
+				"2023-09-04", "2023-10-09", "2023-11-23", "2023-12-25"},
+		},
+		{
+			// June 19 and December 25 are Saturdays, July 4 is a Sunday
+			year: 2027,
+			want: []string{"2027-01-01", "2027-01-18", "2027-02-15", "2027-05-31", "2027-07-05", "2027-09-06",
+				"2027-10-11", "2027-11-11", "2027-11-25"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.year), func(t *testing.T) {
+			var got []string
+			for _, holiday := range Holidays(tt.year) {
+				got = append(got, holiday.Format("2006-01-02"))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Holidays(%d) = %v, want %v", tt.year, got, tt.want)
+			}
+
+			for _, day := range tt.want {
+				date, _ := time.Parse("2006-01-02", day)
+				if !IsHoliday(date) || IsBankingDay(date) {
+					t.Errorf("IsHoliday(%s) = %v, IsBankingDay(%s) = %v, want true, false", day, IsHoliday(date), day, IsBankingDay(date))
+				}
+			}
+		})
+	}
+}
+
+func TestBankingDays(t *testing.T) {
+	eastern := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{name: "next of a banking day", got: NextBankingDay(date(2026, 10, 16)), want: date(2026, 10, 16)},
+		{name: "next of a Saturday", got: NextBankingDay(date(2026, 10, 17)), want: date(2026, 10, 19)},
+		{name: "next of a holiday before a weekend", got: NextBankingDay(date(2026, 12, 25)), want: date(2026, 12, 28)},
+		{name: "next keeps the time of day", got: NextBankingDay(time.Date(2026, 10, 17, 15, 4, 0, 0, eastern)), want: time.Date(2026, 10, 19, 15, 4, 0, 0, eastern)},
+		{name: "add over a weekend", got: AddBankingDays(date(2026, 10, 16), 1), want: date(2026, 10, 19)},
+		{name: "add over a holiday", got: AddBankingDays(date(2026, 11, 10), 2), want: date(2026, 11, 13)},
+		{name: "subtract over a holiday", got: AddBankingDays(date(2026, 12, 28), -1), want: date(2026, 12, 24)},
+		{name: "add zero", got: AddBankingDays(date(2026, 10, 17), 0), want: date(2026, 10, 17)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package nacha
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rashintha/nacha/types"
+)
+
+// Parse reads a NACHA file from r and returns it as a fully populated NachaFile.
+// Records may be separated by LF or CRLF line endings, or be written back to back without separators,
+// and a trailing end of file (0x1A) character is ignored.
+// Offset entries of balanced files are read as regular entries. GenerateOffset recognizes them by their
+// settlement account when the file is generated again with the same Offset.
+func Parse(r io.Reader) (*types.NachaFile, error) {
+	file := &types.NachaFile{}
+
+	var batch *types.NachaBatch
+	var entry *types.NachaEntry
+	hasHeader := false
+	hasControl := false
+
+	scanner := NewScanner(r)
+
+	for scanner.Scan() {
+		record := scanner.Record()
+
+		var err error
+		switch value := record.Value.(type) {
+		case *types.NachaFileHeader:
+			if hasHeader {
+				err = errors.New("duplicate file header")
+				break
+			}
+			file.Header = *value
+			hasHeader = true
+		case *types.NachaBatchHeader:
+			if batch != nil {
+				err = errors.New("batch header found before the previous batch control")
+				break
+			}
+			batch = &types.NachaBatch{Header: *value}
+			entry = nil
+			file.Batches = append(file.Batches, batch)
+		case *types.NachaEntry:
+			if batch == nil {
+				err = errors.New("entry found outside of a batch")
+				break
+			}
+			entry = value
+			batch.Entries = append(batch.Entries, entry)
+		case *types.NachaAddenda:
+			if entry == nil {
+				err = errors.New("addenda found without a preceding entry")
+				break
+			}
+			entry.Addenda = append(entry.Addenda, value)
+		case *types.NachaBatchControl:
+			if batch == nil {
+				err = errors.New("batch control found outside of a batch")
+				break
+			}
+			batch.Control = *value
+			batch = nil
+			entry = nil
+		case *types.NachaFileControl:
+			if batch != nil {
+				err = errors.New("file control found before the batch control")
+				break
+			}
+			file.Control = *value
+			hasControl = true
+		case *types.NachaBlockFiller:
+			file.BlockFillers = append(file.BlockFillers, value)
+		}
+
+		if err == nil && !hasHeader {
+			err = errors.New("file must start with a file header")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", record.Line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !hasHeader {
+		return nil, errors.New("file header not found")
+	}
+	if !hasControl {
+		return nil, errors.New("file control not found")
+	}
+
+	return file, nil
+}
+
+// ParseString parses a NACHA file from its string representation
+func ParseString(s string) (*types.NachaFile, error) {
+	return Parse(strings.NewReader(s))
+}
@@ -0,0 +1,315 @@
+package nacha
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rashintha/nacha/types"
+)
+
+// newTestFile builds a generated file with one batch of two debit entries, the second one with an addenda record
+func newTestFile(t *testing.T) *types.NachaFile {
+	t.Helper()
+
+	file := NewFile()
+	must(t, file.Header.SetImmediateDestination("021000021"))
+	must(t, file.Header.SetImmediateDestinationName("Destination Bank"))
+	must(t, file.Header.SetImmediateOrigin("011000015"))
+	must(t, file.Header.SetImmediateOriginName("Origin Bank"))
+
+	batch := file.NewBatch()
+	must(t, batch.Header.SetServiceClassCode(225))
+	must(t, batch.Header.SetCompanyName("ABC Company"))
+	must(t, batch.Header.SetCompanyIdentification("1122334455"))
+	must(t, batch.Header.SetStandardEntryClassCode("CCD"))
+	must(t, batch.Header.SetCompanyEntryDescription("Payroll"))
+	batch.Header.SetEffectiveEntryDate(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	must(t, batch.Header.SetODFIIdentification("01100001"))
+	must(t, batch.Header.SetBatchNumber(1))
+
+	for i, account := range []string{"29079117", "18076850"} {
+		entry := batch.AddEntry()
+		must(t, entry.SetTransactionCode(27))
+		must(t, entry.SetReceivingDFI("021000021"))
+		must(t, entry.SetDFIAccountNumber(account))
+		must(t, entry.SetAmount(types.Dollars(100, 25)))
+		must(t, entry.SetIndividualIDNumber("392344"))
+		must(t, entry.SetIndividualName("BBC Company"))
+		must(t, entry.SetTraceNumber("01100001", i+1))
+	}
+	batch.Entries[1].NewAddenda().SetPaymentRelatedInformation("Invoice 42")
+
+	must(t, file.GenerateFile())
+	return file
+}
+
+// must fails the test when err is not nil
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	original := newTestFile(t).String()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "LF", input: original},
+		{name: "CRLF", input: strings.ReplaceAll(original, "\n", "\r\n")},
+		{name: "no separators", input: strings.ReplaceAll(original, "\n", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := ParseString(tt.input)
+			if err != nil {
+				t.Fatalf("ParseString() error = %v", err)
+			}
+			if got := file.String(); got != original {
+				t.Errorf("ParseString().String() =\n%s\nwant\n%s", got, original)
+			}
+			if len(file.Batches) != 1 || len(file.Batches[0].Entries) != 2 || len(file.Batches[0].Entries[1].Addenda) != 1 {
+				t.Errorf("ParseString() did not rebuild the batch, entries and addenda")
+			}
+			if err := file.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseEndOfFileCharacter(t *testing.T) {
+	original := newTestFile(t).String()
+
+	for _, input := range []string{original + "\x1a", strings.TrimSuffix(original, "\n") + "\x1a", original + "\r\n\x1a"} {
+		file, err := ParseString(input)
+		if err != nil {
+			t.Fatalf("ParseString() error = %v", err)
+		}
+		if got := file.String(); got != original {
+			t.Errorf("ParseString().String() =\n%s\nwant\n%s", got, original)
+		}
+	}
+}
+
+func TestParseBalancedFile(t *testing.T) {
+	offset := &types.NachaOffset{
+		RoutingNumber:    "011000015",
+		DFIAccountNumber: "123456789",
+		AccountType:      types.AccountTypeChecking,
+		Name:             "ABC Company",
+	}
+
+	original := newTestFile(t)
+	original.Offset = offset
+	must(t, original.GenerateFile())
+
+	file, err := ParseString(original.String())
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	file.Offset = offset
+
+	for _, f := range []*types.NachaFile{original, file} {
+		must(t, f.Batches[0].Entries[0].SetAmount(types.Dollars(250, 0)))
+		must(t, f.GenerateFile())
+	}
+
+	if got, want := len(file.Batches[0].Entries), len(original.Batches[0].Entries); got != want {
+		t.Errorf("parsed balanced file has %d entries after GenerateFile, want %d", got, want)
+	}
+	if got, want := file.String(), original.String(); got != want {
+		t.Errorf("parsed balanced file after GenerateFile =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseIAT(t *testing.T) {
+	original := NewFile()
+	must(t, original.Header.SetImmediateDestination("021000021"))
+	must(t, original.Header.SetImmediateDestinationName("Destination Bank"))
+	must(t, original.Header.SetImmediateOrigin("011000015"))
+	must(t, original.Header.SetImmediateOriginName("Origin Bank"))
+
+	batch := original.NewBatch()
+	must(t, batch.Header.SetServiceClassCode(220))
+	batch.Header.SetIATIndicator()
+	must(t, batch.Header.SetForeignExchange("FV", 3, ""))
+	must(t, batch.Header.SetISODestinationCountryCode("CA"))
+	must(t, batch.Header.SetOriginatorIdentification("1122334455"))
+	must(t, batch.Header.SetStandardEntryClassCode("IAT"))
+	must(t, batch.Header.SetCompanyEntryDescription("Payroll"))
+	must(t, batch.Header.SetISOCurrencyCodes("USD", "CAD"))
+	batch.Header.SetEffectiveEntryDate(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	must(t, batch.Header.SetODFIIdentification("01100001"))
+	must(t, batch.Header.SetBatchNumber(1))
+
+	entry := batch.AddEntry()
+	must(t, entry.SetTransactionCode(22))
+	must(t, entry.SetReceivingDFI("021000021"))
+	must(t, entry.SetAmount(types.Dollars(100, 25)))
+	must(t, entry.SetForeignReceiverAccountNumber("CA29079117000000042"))
+	entry.SetOFACScreeningIndicators(false, false)
+	must(t, entry.SetTraceNumber("01100001", 1))
+	for _, a := range []types.IATAddenda{
+		types.IATAddenda10{TransactionTypeCode: "BUS", ReceiverName: "BBC Company"},
+		types.IATAddenda11{OriginatorName: "ABC Company", OriginatorStreetAddress: "1 Main Street"},
+		types.IATAddenda12{OriginatorCity: "New York", OriginatorStateProvince: "NY", OriginatorCountry: "US", OriginatorPostalCode: "10001"},
+		types.IATAddenda13{ODFIName: "Origin Bank", ODFIIDNumberQualifier: "01", ODFIIdentification: "011000015", ODFIBranchCountryCode: "US"},
+		types.IATAddenda14{RDFIName: "Receiving Bank", RDFIIDNumberQualifier: "02", RDFIIdentification: "BBCBCATT", RDFIBranchCountryCode: "CA"},
+		types.IATAddenda15{ReceiverStreetAddress: "2 Bay Street"},
+		types.IATAddenda16{ReceiverCity: "Toronto", ReceiverCountry: "CA"},
+		types.IATAddenda17{PaymentRelatedInformation: "Invoice 42"},
+	} {
+		if _, err := entry.AddIATAddenda(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(t, original.GenerateFile())
+
+	file, err := ParseString(original.String())
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	if got := file.String(); got != original.String() {
+		t.Errorf("ParseString().String() =\n%s\nwant\n%s", got, original.String())
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	header := file.Batches[0].Header
+	parsed := file.Batches[0].Entries[0]
+	count, err := parsed.IATNumberOfAddendaRecords()
+	if err != nil || count != 8 || len(parsed.Addenda) != 8 {
+		t.Errorf("IATNumberOfAddendaRecords() = %d, %v, with %d addenda records, want 8", count, err, len(parsed.Addenda))
+	}
+	if parsed.ForeignReceiverAccountNumber() != "CA29079117000000042" || header.ISODestinationCountryCode() != "CA" ||
+		header.ISODestinationCurrencyCode() != "CAD" || parsed.Addenda[7].AddendaSequenceNumber != "0001" {
+		t.Errorf("ParseString() did not keep the IAT fields: %q, %q, %q, %q", parsed.ForeignReceiverAccountNumber(),
+			header.ISODestinationCountryCode(), header.ISODestinationCurrencyCode(), parsed.Addenda[7].AddendaSequenceNumber)
+	}
+}
+
+func TestParseReturn(t *testing.T) {
+	original := newTestFile(t).Batches[0].Entries[0]
+	entry, err := types.NewReturnEntry(original, "R15", time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewReturnEntry() error = %v", err)
+	}
+
+	returns := newTestFile(t)
+	batch := returns.Batches[0]
+	must(t, batch.Header.SetODFIIdentification("02100002"))
+	must(t, entry.SetTraceNumber("02100002", 1))
+	batch.Entries = []*types.NachaEntry{entry}
+	must(t, returns.GenerateFile())
+
+	file, err := ParseString(returns.String())
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	if got := file.String(); got != returns.String() {
+		t.Errorf("ParseString().String() =\n%s\nwant\n%s", got, returns.String())
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	parsed := file.Batches[0].Entries[0]
+	if !parsed.IsReturn() || len(parsed.Addenda) != 1 {
+		t.Fatalf("ParseString() entry = %q with %d addenda records, want a return", parsed.String(), len(parsed.Addenda))
+	}
+	addenda := parsed.Addenda[0]
+	date, err := addenda.DateOfDeath()
+	if addenda.ReturnReasonCode() != "R15" || addenda.OriginalEntryTraceNumber() != original.TraceNumber ||
+		addenda.AddendaTraceNumber() != parsed.TraceNumber || err != nil || date.Format("060102") != "260930" {
+		t.Errorf("ParseString() return addenda = %q", addenda.String())
+	}
+}
+
+func TestParseNOC(t *testing.T) {
+	original := newTestFile(t).Batches[0].Entries[0]
+	corrected := types.Receiver{CompanyName: "ABC Corp", CompanyIdentification: "9988776655"}
+	entry, err := types.NewNOCEntry(original, "C12", corrected)
+	if err != nil {
+		t.Fatalf("NewNOCEntry() error = %v", err)
+	}
+
+	notifications := newTestFile(t)
+	batch := notifications.Batches[0]
+	must(t, batch.Header.SetStandardEntryClassCode("COR"))
+	must(t, batch.Header.SetODFIIdentification("02100002"))
+	must(t, entry.SetTraceNumber("02100002", 1))
+	batch.Entries = []*types.NachaEntry{entry}
+	must(t, notifications.GenerateFile())
+
+	file, err := ParseString(notifications.String())
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	if got := file.String(); got != notifications.String() {
+		t.Errorf("ParseString().String() =\n%s\nwant\n%s", got, notifications.String())
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	parsed := file.Batches[0].Entries[0]
+	if len(parsed.Addenda) != 1 {
+		t.Fatalf("ParseString() entry has %d addenda records, want 1", len(parsed.Addenda))
+	}
+	got, err := parsed.Addenda[0].Correction()
+	if err != nil || got.CompanyName != "ABC CORP" || got.CompanyIdentification != "9988776655" {
+		t.Errorf("Correction() = %+v, %v", got, err)
+	}
+}
+
+func TestParseGenerateTwice(t *testing.T) {
+	original := newTestFile(t)
+	must(t, original.GenerateFile())
+	want := original.String()
+
+	input := want
+	for i := range 2 {
+		file, err := ParseString(input)
+		if err != nil {
+			t.Fatalf("ParseString() pass %d error = %v", i+1, err)
+		}
+		must(t, file.GenerateFile())
+
+		input = file.String()
+		if input != want {
+			t.Errorf("ParseString() and GenerateFile() pass %d =\n%s\nwant\n%s", i+1, input, want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(newTestFile(t).String(), "\n"), "\n")
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: "file header not found"},
+		{name: "truncated", input: strings.Join(lines[:5], "\n"), want: "file control not found"},
+		{name: "short record", input: strings.Join(append([]string{lines[0], lines[1][:50]}, lines[2:]...), "\n"), want: "line 2: record must be 94 characters, got 50"},
+		{name: "no file header", input: strings.Join(lines[1:], "\n"), want: "line 1: file must start with a file header"},
+		{name: "entry outside batch", input: strings.Join([]string{lines[0], lines[2]}, "\n"), want: "line 2: entry found outside of a batch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseString(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseString() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package routing
+
+import (
+	"errors"
+	"strconv"
+)
+
+// weights are the ABA checksum weights applied to the 9 digits of a routing number
+var weights = [9]int{3, 7, 1, 3, 7, 1, 3, 7, 1}
+
+// CheckDigit computes the check digit for the first 8 digits of an ABA routing number
+func CheckDigit(prefix string) (int, error) {
+	if len(prefix) != 8 {
+		return 0, errors.New("routing number prefix must be 8 digits")
+	}
+	if !isDigits(prefix) {
+		return 0, errors.New("routing number prefix must be numeric")
+	}
+
+	sum := 0
+	for i := range 8 {
+		sum += int(prefix[i]-'0') * weights[i]
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// Validate checks that the routing number is 9 digits and that its last digit matches the 3-7-1 ABA checksum
+func Validate(routingNumber string) error {
+	if len(routingNumber) != 9 {
+		return errors.New("routing number must be 9 digits")
+	}
+	if !isDigits(routingNumber) {
+		return errors.New("routing number must be numeric")
+	}
+
+	digit, _ := CheckDigit(routingNumber[:8])
+	if strconv.Itoa(digit) != routingNumber[8:] {
+		return errors.New("routing number check digit must be " + strconv.Itoa(digit))
+	}
+
+	return nil
+}
+
+// IsValid reports whether the routing number is 9 digits with a valid ABA checksum
+func IsValid(routingNumber string) bool {
+	return Validate(routingNumber) == nil
+}
+
+// Split validates the routing number and splits it into its 8 digit DFI identification and its check digit
+func Split(routingNumber string) (string, string, error) {
+	if err := Validate(routingNumber); err != nil {
+		return "", "", err
+	}
+
+	return routingNumber[:8], routingNumber[8:], nil
+}
+
+// isDigits reports whether the value only contains digits
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,80 @@
+package routing
+
+import "testing"
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		want    int
+		wantErr string
+	}{
+		{prefix: "02100002", want: 1},
+		{prefix: "01100001", want: 5},
+		{prefix: "12200066", want: 1},
+		{prefix: "0210000", wantErr: "routing number prefix must be 8 digits"},
+		{prefix: "021000021", wantErr: "routing number prefix must be 8 digits"},
+		{prefix: "0210000A", wantErr: "routing number prefix must be numeric"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			got, err := CheckDigit(tt.prefix)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("CheckDigit(%q) error = %v, want %q", tt.prefix, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("CheckDigit(%q) = %d, %v, want %d", tt.prefix, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		routingNumber string
+		wantErr       string
+	}{
+		{routingNumber: "021000021"},
+		{routingNumber: "011000015"},
+		{routingNumber: "122000661"},
+		{routingNumber: "021000022", wantErr: "routing number check digit must be 1"},
+		{routingNumber: "011000010", wantErr: "routing number check digit must be 5"},
+		{routingNumber: "02100002A", wantErr: "routing number must be numeric"},
+		{routingNumber: "O21000021", wantErr: "routing number must be numeric"},
+		{routingNumber: "02100002", wantErr: "routing number must be 9 digits"},
+		{routingNumber: "0210000211", wantErr: "routing number must be 9 digits"},
+		{routingNumber: "", wantErr: "routing number must be 9 digits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.routingNumber, func(t *testing.T) {
+			err := Validate(tt.routingNumber)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate(%q) error = %v, want nil", tt.routingNumber, err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, want %q", tt.routingNumber, err, tt.wantErr)
+			}
+
+			if got := IsValid(tt.routingNumber); got != (tt.wantErr == "") {
+				t.Errorf("IsValid(%q) = %v, want %v", tt.routingNumber, got, tt.wantErr == "")
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	id, digit, err := Split("021000021")
+	if err != nil || id != "02100002" || digit != "1" {
+		t.Errorf("Split(\"021000021\") = %q, %q, %v, want \"02100002\", \"1\", nil", id, digit, err)
+	}
+
+	id, digit, err = Split("021000022")
+	if err == nil || id != "" || digit != "" {
+		t.Errorf("Split(\"021000022\") = %q, %q, %v, want an error", id, digit, err)
+	}
+}
@@ -0,0 +1,145 @@
+package nacha
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/rashintha/nacha/types"
+)
+
+// Record is a single typed record read by a Scanner
+type Record struct {
+	Line   int   // Line number of the record, starting at 1
+	Offset int64 // Byte offset of the first character of the record
+
+	// Value is one of *types.NachaFileHeader, *types.NachaBatchHeader, *types.NachaEntry,
+	// *types.NachaAddenda, *types.NachaBatchControl, *types.NachaFileControl or *types.NachaBlockFiller
+	Value any
+}
+
+// Scanner reads the records of a NACHA file one at a time from an io.Reader without
+// holding the whole file in memory. Records may be separated by LF or CRLF line endings,
+// or be written back to back without separators, and end of file (0x1A) characters are skipped.
+type Scanner struct {
+	scanner *bufio.Scanner
+	record  Record
+	err     error
+
+	lines      int   // Number of line feeds consumed so far
+	offset     int64 // Number of bytes consumed so far
+	nextLine   int
+	nextOffset int64
+
+	hasControl bool
+}
+
+// NewScanner returns a new Scanner reading from r
+func NewScanner(r io.Reader) *Scanner {
+	s := &Scanner{}
+	s.scanner = bufio.NewScanner(r)
+	s.scanner.Split(s.split)
+	return s
+}
+
+// Scan advances the Scanner to the next record, which will then be available through Record.
+// It returns false when the scan stops, either by reaching the end of the input or an error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	if !s.scanner.Scan() {
+		s.err = s.scanner.Err()
+		return false
+	}
+
+	s.record = Record{Line: s.nextLine, Offset: s.nextOffset}
+
+	value, err := s.parseRecord(s.scanner.Text())
+	if err != nil {
+		s.err = fmt.Errorf("line %d: %w", s.record.Line, err)
+		return false
+	}
+
+	s.record.Value = value
+	return true
+}
+
+// Record returns the most recent record read by Scan
+func (s *Scanner) Record() Record {
+	return s.record
+}
+
+// Err returns the first error encountered by the Scanner
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// parseRecord converts a raw record into its typed value
+func (s *Scanner) parseRecord(record string) (any, error) {
+	if len(record) != types.RecordLength {
+		return nil, fmt.Errorf("record must be %d characters, got %d", types.RecordLength, len(record))
+	}
+
+	switch {
+	case s.hasControl:
+		filler := &types.NachaBlockFiller{}
+		return filler, filler.Parse(record)
+	case record[0] == '1':
+		header := &types.NachaFileHeader{}
+		return header, header.Parse(record)
+	case record[0] == '5':
+		header := &types.NachaBatchHeader{}
+		return header, header.Parse(record)
+	case record[0] == '6':
+		entry := &types.NachaEntry{}
+		return entry, entry.Parse(record)
+	case record[0] == '7':
+		addenda := &types.NachaAddenda{}
+		return addenda, addenda.Parse(record)
+	case record[0] == '8':
+		control := &types.NachaBatchControl{}
+		return control, control.Parse(record)
+	case record[0] == '9':
+		s.hasControl = true
+		control := &types.NachaFileControl{}
+		return control, control.Parse(record)
+	}
+
+	return nil, fmt.Errorf("unknown record type %q", record[0])
+}
+
+// endOfFile is the end of file (SUB) character some systems append to NACHA files, it is skipped like a line separator
+const endOfFile = 0x1A
+
+// split is a bufio.SplitFunc that returns one NACHA record per token and keeps track of
+// the line and byte offset of the returned record.
+// Line separators and end of file characters are skipped, and lines longer than a record are split every types.RecordLength characters.
+func (s *Scanner) split(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	for start < len(data) && (data[start] == '\n' || data[start] == '\r' || data[start] == endOfFile) {
+		start++
+	}
+
+	rest := data[start:]
+	advance = start
+	switch i := bytes.IndexByte(rest, '\n'); {
+	case len(rest) == 0:
+	case i >= 0 && i <= types.RecordLength+1:
+		advance, token = start+i+1, bytes.TrimRight(rest[:i], "\r")
+	case len(rest) >= types.RecordLength:
+		advance, token = start+types.RecordLength, rest[:types.RecordLength]
+	case atEOF:
+		advance, token = len(data), rest
+	}
+
+	if token != nil {
+		s.nextLine = s.lines + bytes.Count(data[:start], []byte{'\n'}) + 1
+		s.nextOffset = s.offset + int64(start)
+	}
+
+	s.lines += bytes.Count(data[:advance], []byte{'\n'})
+	s.offset += int64(advance)
+	return advance, token, nil
+}
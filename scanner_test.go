@@ -0,0 +1,47 @@
+package nacha
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rashintha/nacha/types"
+)
+
+func TestScannerPositions(t *testing.T) {
+	input := strings.ReplaceAll(newTestFile(t).String(), "\n", "\r\n")
+
+	scanner := NewScanner(strings.NewReader(input))
+	count := 0
+	for scanner.Scan() {
+		record := scanner.Record()
+		if want := count + 1; record.Line != want {
+			t.Errorf("record %d Line = %d, want %d", count, record.Line, want)
+		}
+		if want := int64(count * (types.RecordLength + 2)); record.Offset != want {
+			t.Errorf("record %d Offset = %d, want %d", count, record.Offset, want)
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if count != 10 {
+		t.Errorf("scanned %d records, want 10", count)
+	}
+}
+
+func TestScannerEndOfFileCharacter(t *testing.T) {
+	input := newTestFile(t).String() + "\x1a"
+
+	scanner := NewScanner(strings.NewReader(input))
+	count := 0
+	for scanner.Scan() {
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if count != 10 {
+		t.Errorf("scanned %d records, want 10", count)
+	}
+}
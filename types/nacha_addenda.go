@@ -11,9 +11,9 @@ import (
 // NachaAddenda represents the NACHA Addenda (Type 7)
 type NachaAddenda struct {
 	Type                      string // Char Count: 1 | Fixed Value: 7
-	AddendaTypeCode           string // Char Count: 2 | Values: 05 - PPD & CCD
+	AddendaTypeCode           string // Char Count: 2 | Values: 05 - PPD, CCD, WEB & CTX, 10 to 18 - IAT, 98 - Notification of Change, 99 - Return
 	PaymentRelatedInformation string // Char Count: 80 | Optional
-	AddendaSequenceNumber     string // Char Count: 4 | Values: 1 - 9999
+	AddendaSequenceNumber     string // Char Count: 4 | Values: 1 - 9999 | IAT Addenda 10 to 16: Blank | Return & Notification of Change: Part of the Trace Number
 	EntryDetailSequenceNumber string // Char Count: 7 | Values: Same as Entry Detail Record Sequence Number
 }
 
@@ -59,6 +59,18 @@ func (a *NachaAddenda) SetAddendaSequenceNumber(seq int) error {
 	return nil
 }
 
+// hasAddendaSequenceNumber reports whether the addenda type carries an AddendaSequenceNumber.
+// IAT addenda 10 to 16 reserve those positions instead, and return and notification of change addenda
+// use them for their Trace Number.
+func (a *NachaAddenda) hasAddendaSequenceNumber() bool {
+	switch a.AddendaTypeCode {
+	case "10", "11", "12", "13", "14", "15", "16", "98", "99":
+		return false
+	}
+
+	return true
+}
+
 // SetEntryDetailSequenceNumber sets the EntryDetailSequenceNumber
 func (a *NachaAddenda) SetEntryDetailSequenceNumber(seq int) error {
 	if seq < 1 || seq > 9999999 {
@@ -68,3 +80,33 @@ func (a *NachaAddenda) SetEntryDetailSequenceNumber(seq int) error {
 	a.EntryDetailSequenceNumber = util.ToFixedWidthZeroString(strconv.Itoa(seq), 7)
 	return nil
 }
+
+// Parse populates the NachaAddenda from a 94 character record
+func (a *NachaAddenda) Parse(record string) error {
+	if err := checkRecord(record, "7"); err != nil {
+		return err
+	}
+
+	a.Type = record[0:1]
+	a.AddendaTypeCode = record[1:3]
+	a.PaymentRelatedInformation = record[3:83]
+	a.AddendaSequenceNumber = record[83:87]
+	a.EntryDetailSequenceNumber = record[87:94]
+	return nil
+}
+
+// String returns the NachaAddenda as a 94 character record
+func (a *NachaAddenda) String() string {
+	return a.Type + a.AddendaTypeCode + a.PaymentRelatedInformation +
+		a.AddendaSequenceNumber + a.EntryDetailSequenceNumber
+}
+
+// AddendaSequenceNumberValue returns the AddendaSequenceNumber as an int
+func (a *NachaAddenda) AddendaSequenceNumberValue() (int, error) {
+	return parseIntField("AddendaSequenceNumber", a.AddendaSequenceNumber)
+}
+
+// EntryDetailSequenceNumberValue returns the EntryDetailSequenceNumber as an int
+func (a *NachaAddenda) EntryDetailSequenceNumberValue() (int, error) {
+	return parseIntField("EntryDetailSequenceNumber", a.EntryDetailSequenceNumber)
+}
@@ -0,0 +1,16 @@
+package types
+
+import "testing"
+
+func TestAddendaGetters(t *testing.T) {
+	addenda := &NachaAddenda{}
+	must(t, addenda.Parse("705INVOICE 42                                                                      00020000042"))
+	malformed := &NachaAddenda{AddendaSequenceNumber: "00 2", EntryDetailSequenceNumber: "-000042"}
+
+	runGetterTests(t, []getterTest{
+		{name: "AddendaSequenceNumberValue", get: func() (any, error) { return addenda.AddendaSequenceNumberValue() }, want: 2},
+		{name: "EntryDetailSequenceNumberValue", get: func() (any, error) { return addenda.EntryDetailSequenceNumberValue() }, want: 42},
+		{name: "malformed AddendaSequenceNumber", get: func() (any, error) { return malformed.AddendaSequenceNumberValue() }, wantErr: "AddendaSequenceNumber must be numeric"},
+		{name: "malformed EntryDetailSequenceNumber", get: func() (any, error) { return malformed.EntryDetailSequenceNumberValue() }, wantErr: "EntryDetailSequenceNumber must be numeric"},
+	})
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/rashintha/nacha/util"
+)
+
+// Amount is an exact amount of money in cents
+type Amount int64
+
+// Dollars returns an Amount of whole dollars and cents
+func Dollars(dollars int64, cents int64) Amount {
+	return Amount(dollars*100 + cents)
+}
+
+// AmountFromFloat converts a float64 dollar amount to an Amount, rounding to the nearest cent
+func AmountFromFloat(amount float64) Amount {
+	return Amount(math.Round(amount * 100))
+}
+
+// ParseAmount parses a decimal dollar amount such as "1234.56", "0.29" or "15" without any loss of precision
+func ParseAmount(s string) (Amount, error) {
+	value := strings.TrimSpace(s)
+	if value == "" {
+		return 0, errors.New("amount cannot be empty")
+	}
+
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	dollars, cents, hasCents := strings.Cut(value, ".")
+	if dollars == "" && cents == "" {
+		return 0, errors.New("amount must be a decimal number")
+	}
+	if dollars == "" {
+		dollars = "0"
+	}
+	if hasCents && (len(cents) < 1 || len(cents) > 2) {
+		return 0, errors.New("amount must have at most 2 decimal places")
+	}
+	if len(cents) == 1 {
+		cents += "0"
+	}
+
+	if !isDigits(dollars) || (cents != "" && !isDigits(cents)) {
+		return 0, errors.New("amount must be a decimal number")
+	}
+
+	d, err := strconv.ParseInt(dollars, 10, 64)
+	if err != nil || d > math.MaxInt64/100-1 {
+		return 0, errors.New("amount is too large")
+	}
+
+	c := int64(0)
+	if cents != "" {
+		c, _ = strconv.ParseInt(cents, 10, 64)
+	}
+
+	amount := Amount(d*100 + c)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// Cents returns the Amount as a number of cents
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+// String returns the Amount as a decimal dollar amount such as "1234.56"
+func (a Amount) String() string {
+	sign := ""
+	cents := int64(a)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return sign + strconv.FormatInt(cents/100, 10) + "." + util.ToFixedWidthZeroString(strconv.FormatInt(cents%100, 10), 2)
+}
+
+// format returns the Amount in cents as a zero padded field of the given width
+func (a Amount) format(width int) string {
+	return util.ToFixedWidthZeroString(strconv.FormatInt(int64(a), 10), width)
+}
+
+// parseAmountField parses a zero padded amount field in cents
+func parseAmountField(field string, value string) (Amount, error) {
+	cents, err := parseNumberField(field, value)
+	return Amount(cents), err
+}
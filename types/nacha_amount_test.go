@@ -0,0 +1,50 @@
+package types
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Amount
+		wantErr string
+	}{
+		{input: "1234.29", want: 123429},
+		{input: "0.29", want: 29},
+		{input: ".5", want: 50},
+		{input: "12", want: 1200},
+		{input: " 12.3 ", want: 1230},
+		{input: "-12.34", want: -1234},
+		{input: "", wantErr: "amount cannot be empty"},
+		{input: "-", wantErr: "amount must be a decimal number"},
+		{input: "-.", wantErr: "amount must be a decimal number"},
+		{input: ".", wantErr: "amount must be a decimal number"},
+		{input: "1.234", wantErr: "amount must have at most 2 decimal places"},
+		{input: "1.", wantErr: "amount must have at most 2 decimal places"},
+		{input: "1,000.00", wantErr: "amount must be a decimal number"},
+		{input: "92233720368547758.07", wantErr: "amount is too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAmount(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ParseAmount(%q) = %d, %v, want error %q", tt.input, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseAmount(%q) = %d, %v, want %d", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountFromFloat(t *testing.T) {
+	if got := AmountFromFloat(0.29); got != 29 {
+		t.Errorf("AmountFromFloat(0.29) = %d, want 29", got)
+	}
+	if got := Dollars(1234, 29).String(); got != "1234.29" {
+		t.Errorf("Dollars(1234, 29).String() = %q, want \"1234.29\"", got)
+	}
+}
@@ -11,6 +11,10 @@ type NachaBatch struct {
 	Header  NachaBatchHeader
 	Entries []*NachaEntry
 	Control NachaBatchControl
+
+	Offset *NachaOffset // Optional | Settlement account used to balance the batch when the file is generated
+
+	DeriveServiceClassCode bool // Optional | Sets the ServiceClassCode from the entries when the batch control is generated
 }
 
 // AddEntry adds a new NachaEntry to the batch and appends it to the batch's Entries'
@@ -21,14 +25,75 @@ func (b *NachaBatch) AddEntry() *NachaEntry {
 	return entry
 }
 
+// GenerateAddenda renumbers the addenda records of every entry and links them to the entry TraceNumber.
+// The Number of Addenda Records of CTX and IAT entries is updated to match, and an error is returned
+// when an entry carries more addenda records than a CTX entry allows or not 7 to 14 IAT addenda records.
+func (b *NachaBatch) GenerateAddenda() error {
+	for _, entry := range b.Entries {
+		entry.RenumberAddenda()
+
+		var err error
+		switch b.Header.StandardEntryClassCode {
+		case "CTX":
+			err = entry.SetNumberOfAddendaRecords(len(entry.Addenda))
+		case "IAT":
+			err = entry.SetIATNumberOfAddendaRecords(len(entry.Addenda))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GenerateBatchControl generates the BatchControl
+// The ServiceClassCode of the header is derived from the entries first when DeriveServiceClassCode is set
 func (b *NachaBatch) GenerateBatchControl() {
+	if b.DeriveServiceClassCode {
+		if code, ok := b.serviceClassCode(); ok {
+			b.Header.ServiceClassCode = code
+		}
+	}
 	b.Control.ServiceClassCode = b.Header.ServiceClassCode
 
-	entriesAddendaCount := len(b.Entries)
-	entryHash := int64(0)
-	totalDebits := int64(0)
-	totalCredits := int64(0)
+	entriesAddendaCount, entryHash, totalDebits, totalCredits := b.totals()
+
+	b.Control.EntryAddendaCount = util.ToFixedWidthZeroString(strconv.Itoa(entriesAddendaCount), 6)
+	b.Control.EntryHash = formatEntryHash(entryHash)
+	b.Control.TotalDebits = totalDebits.format(12)
+	b.Control.TotalCredits = totalCredits.format(12)
+
+	b.Control.CompanyIdentification = b.Header.CompanyIdentification
+	b.Control.ODFIIdentification = b.Header.ODFIIdentification
+	b.Control.BatchNumber = b.Header.BatchNumber
+}
+
+// serviceClassCode returns the ServiceClassCode matching the entries of the batch:
+// 220 for credits only, 225 for debits only and 200 for mixed debits and credits.
+// It reports false for a batch without entries.
+func (b *NachaBatch) serviceClassCode() (string, bool) {
+	debits, credits := false, false
+	for _, entry := range b.Entries {
+		debits = debits || entry.IsDebit()
+		credits = credits || entry.IsCredit()
+	}
+
+	switch {
+	case debits && credits:
+		return "200", true
+	case debits:
+		return "225", true
+	case credits:
+		return "220", true
+	}
+
+	return "", false
+}
+
+// totals computes the entry and addenda count, entry hash, total debits and total credits of the batch entries
+func (b *NachaBatch) totals() (entriesAddendaCount int, entryHash int64, totalDebits Amount, totalCredits Amount) {
+	entriesAddendaCount = len(b.Entries)
 
 	for _, entry := range b.Entries {
 		entriesAddendaCount += len(entry.Addenda)
@@ -36,23 +101,21 @@ func (b *NachaBatch) GenerateBatchControl() {
 		RDFINumber, _ := strconv.ParseInt(entry.ReceivingDFIIdentification, 10, 64)
 		entryHash += RDFINumber
 
-		if entry.TransactionCode == "27" || entry.TransactionCode == "28" || entry.TransactionCode == "37" || entry.TransactionCode == "38" {
-			debitAmount, _ := strconv.ParseInt(entry.Amount, 10, 64)
+		if entry.IsDebit() {
+			debitAmount, _ := entry.AmountCents()
 			totalDebits += debitAmount
 		}
 
-		if entry.TransactionCode == "22" || entry.TransactionCode == "23" || entry.TransactionCode == "32" || entry.TransactionCode == "33" {
-			creditAmount, _ := strconv.ParseInt(entry.Amount, 10, 64)
+		if entry.IsCredit() {
+			creditAmount, _ := entry.AmountCents()
 			totalCredits += creditAmount
 		}
 	}
 
-	b.Control.EntryAddendaCount = util.ToFixedWidthZeroString(strconv.Itoa(entriesAddendaCount), 6)
-	b.Control.EntryHash = util.ToFixedWidthZeroString(strconv.Itoa(int(entryHash)), 10)
-	b.Control.TotalDebits = util.ToFixedWidthZeroString(strconv.Itoa(int(totalDebits)), 12)
-	b.Control.TotalCredits = util.ToFixedWidthZeroString(strconv.Itoa(int(totalCredits)), 12)
+	return entriesAddendaCount, entryHash, totalDebits, totalCredits
+}
 
-	b.Control.CompanyIdentification = b.Header.CompanyIdentification
-	b.Control.ODFIIdentification = b.Header.ODFIIdentification
-	b.Control.BatchNumber = b.Header.BatchNumber
+// formatEntryHash formats an entry hash as the 10 low-order digits of the sum of the hashed values
+func formatEntryHash(hash int64) string {
+	return util.ToFixedWidthZeroString(strconv.FormatInt(hash%10000000000, 10), 10)
 }
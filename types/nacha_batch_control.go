@@ -68,29 +68,41 @@ func (b *NachaBatchControl) SetEntryHash(hash int64) error {
 }
 
 // SetTotalDebits sets the TotalDebits
-func (b *NachaBatchControl) SetTotalDebits(amount float64) error {
+func (b *NachaBatchControl) SetTotalDebits(amount Amount) error {
 	if amount < 0 {
 		return errors.New("TotalDebits must be greater than or equal to 0")
 	}
-	if amount > 99999999999999.99 {
-		return errors.New("TotalDebits must be less than or equal to 99999999999999.99")
+	if amount > 999999999999 {
+		return errors.New("TotalDebits must be less than or equal to 9999999999.99")
 	}
-	b.TotalDebits = util.ToFixedWidthZeroString(strconv.Itoa(int(amount*100)), 12)
+
+	b.TotalDebits = amount.format(12)
 	return nil
 }
 
+// TotalDebitsCents returns the TotalDebits as an exact number of cents
+func (b *NachaBatchControl) TotalDebitsCents() (Amount, error) {
+	return parseAmountField("TotalDebits", b.TotalDebits)
+}
+
 // SetTotalCredits sets the TotalCredits
-func (b *NachaBatchControl) SetTotalCredits(amount float64) error {
+func (b *NachaBatchControl) SetTotalCredits(amount Amount) error {
 	if amount < 0 {
 		return errors.New("TotalCredits must be greater than or equal to 0")
 	}
-	if amount > 99999999999999.99 {
-		return errors.New("TotalCredits must be less than or equal to 99999999999999.99")
+	if amount > 999999999999 {
+		return errors.New("TotalCredits must be less than or equal to 9999999999.99")
 	}
-	b.TotalCredits = util.ToFixedWidthZeroString(strconv.Itoa(int(amount*100)), 12)
+
+	b.TotalCredits = amount.format(12)
 	return nil
 }
 
+// TotalCreditsCents returns the TotalCredits as an exact number of cents
+func (b *NachaBatchControl) TotalCreditsCents() (Amount, error) {
+	return parseAmountField("TotalCredits", b.TotalCredits)
+}
+
 // SetCompanyIdentification sets the CompanyIdentification
 func (b *NachaBatchControl) SetCompanyIdentification(id string) error {
 	if id == "" {
@@ -126,3 +138,51 @@ func (b *NachaBatchControl) SetODFIIdentification(id string) error {
 	b.ODFIIdentification = util.ToFixedWidthZeroString(id, 7)
 	return nil
 }
+
+// Parse populates the NachaBatchControl from a 94 character record
+func (b *NachaBatchControl) Parse(record string) error {
+	if err := checkRecord(record, "8"); err != nil {
+		return err
+	}
+
+	b.Type = record[0:1]
+	b.ServiceClassCode = record[1:4]
+	b.EntryAddendaCount = record[4:10]
+	b.EntryHash = record[10:20]
+	b.TotalDebits = record[20:32]
+	b.TotalCredits = record[32:44]
+	b.CompanyIdentification = record[44:54]
+	b.MessageAuthenticationCode = record[54:73]
+	b.Reserved = record[73:79]
+	b.ODFIIdentification = record[79:87]
+	b.BatchNumber = record[87:94]
+	return nil
+}
+
+// String returns the NachaBatchControl as a 94 character record
+func (b *NachaBatchControl) String() string {
+	return b.Type + b.ServiceClassCode + b.EntryAddendaCount +
+		b.EntryHash + b.TotalDebits + b.TotalCredits +
+		b.CompanyIdentification + b.MessageAuthenticationCode + b.Reserved +
+		b.ODFIIdentification + b.BatchNumber
+}
+
+// ServiceClassCodeValue returns the ServiceClassCode as an int
+func (b *NachaBatchControl) ServiceClassCodeValue() (int, error) {
+	return parseIntField("ServiceClassCode", b.ServiceClassCode)
+}
+
+// EntryAddendaCountValue returns the EntryAddendaCount as an int
+func (b *NachaBatchControl) EntryAddendaCountValue() (int, error) {
+	return parseIntField("EntryAddendaCount", b.EntryAddendaCount)
+}
+
+// EntryHashValue returns the EntryHash as an int64
+func (b *NachaBatchControl) EntryHashValue() (int64, error) {
+	return parseNumberField("EntryHash", b.EntryHash)
+}
+
+// BatchNumberValue returns the BatchNumber as an int
+func (b *NachaBatchControl) BatchNumberValue() (int, error) {
+	return parseIntField("BatchNumber", b.BatchNumber)
+}
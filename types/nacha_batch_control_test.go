@@ -0,0 +1,23 @@
+package types
+
+import "testing"
+
+func TestBatchControlGetters(t *testing.T) {
+	control := &NachaBatchControl{}
+	must(t, control.Parse("822500000300042000040000002346500000000000001122334455                         011000010000001"))
+	malformed := &NachaBatchControl{ServiceClassCode: "2 5", EntryAddendaCount: "00000A", EntryHash: "", TotalDebits: "1.00", BatchNumber: "x"}
+
+	runGetterTests(t, []getterTest{
+		{name: "ServiceClassCodeValue", get: func() (any, error) { return control.ServiceClassCodeValue() }, want: 225},
+		{name: "EntryAddendaCountValue", get: func() (any, error) { return control.EntryAddendaCountValue() }, want: 3},
+		{name: "EntryHashValue", get: func() (any, error) { return control.EntryHashValue() }, want: int64(4200004)},
+		{name: "TotalDebitsCents", get: func() (any, error) { return control.TotalDebitsCents() }, want: Dollars(2346, 50)},
+		{name: "TotalCreditsCents", get: func() (any, error) { return control.TotalCreditsCents() }, want: Amount(0)},
+		{name: "BatchNumberValue", get: func() (any, error) { return control.BatchNumberValue() }, want: 1},
+		{name: "malformed ServiceClassCode", get: func() (any, error) { return malformed.ServiceClassCodeValue() }, wantErr: "ServiceClassCode must be numeric"},
+		{name: "malformed EntryAddendaCount", get: func() (any, error) { return malformed.EntryAddendaCountValue() }, wantErr: "EntryAddendaCount must be numeric"},
+		{name: "blank EntryHash", get: func() (any, error) { return malformed.EntryHashValue() }, wantErr: "EntryHash must be numeric"},
+		{name: "malformed TotalDebits", get: func() (any, error) { return malformed.TotalDebitsCents() }, wantErr: "TotalDebits must be numeric"},
+		{name: "malformed BatchNumber", get: func() (any, error) { return malformed.BatchNumberValue() }, wantErr: "BatchNumber must be numeric"},
+	})
+}
@@ -2,10 +2,12 @@ package types
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/rashintha/nacha/calendar"
 	"github.com/rashintha/nacha/util"
 )
 
@@ -18,13 +20,13 @@ type NachaBatchHeader struct {
 	CompanyDiscretionaryData string // Char Count: 20 | Optional
 	CompanyIdentification    string // Char Count: 10 | Value: Tax ID or Bank Assigned ID
 
-	StandardEntryClassCode string // Char Count: 3 | Values: PPD or CCD
+	StandardEntryClassCode string // Char Count: 3 | Values: PPD, CCD, WEB, TEL, CTX, IAT or COR
 
 	CompanyEntryDescription string // Char Count: 10 | Values: General identification term (Payroll etc.)
-	CompanyDescriptiveDate  string // Char Count: 6 | Format: YYMMDD | Optional
+	CompanyDescriptiveDate  string // Char Count: 6 | Format: YYMMDD | Optional | Same Day ACH: SDHHMM
 
 	EffectiveEntryDate   string // Char Count: 6 | Format: YYMMDD
-	SettlementDateJulian string // Char Count: 3 | Fixed Value: blank
+	SettlementDateJulian string // Char Count: 3 | Format: DDD | Blank when originated, inserted by the ACH Operator
 	OriginatorStatusCode string // Char Count: 1 | Value Usually: 1
 	ODFIIdentification   string // Char Count: 8 | Value: First 8 digits of the ODFI Routing Number
 	BatchNumber          string // Char Count: 7 | Values: 0000001 - 9999999
@@ -91,8 +93,8 @@ func (h *NachaBatchHeader) SetCompanyIdentification(id string) error {
 
 // SetStandardEntryClassCode sets the StandardEntryClassCode
 func (h *NachaBatchHeader) SetStandardEntryClassCode(code string) error {
-	if code != "PPD" && code != "CCD" {
-		return errors.New("StandardEntryClassCode must be PPD or CCD")
+	if !isStandardEntryClassCode(code) {
+		return errors.New("StandardEntryClassCode must be one of " + strings.Join(standardEntryClassCodes, ", "))
 	}
 
 	h.StandardEntryClassCode = code
@@ -132,6 +134,11 @@ func (h *NachaBatchHeader) SetSettlementDateJulian() {
 	h.SettlementDateJulian = util.ToFixedWidthString("", 3, false)
 }
 
+// SetSettlementDate sets the SettlementDateJulian to the day of the year of the date
+func (h *NachaBatchHeader) SetSettlementDate(date time.Time) {
+	h.SettlementDateJulian = fmt.Sprintf("%03d", date.YearDay())
+}
+
 // SetOriginatorStatusCode sets the OriginatorStatusCode
 func (h *NachaBatchHeader) SetOriginatorStatusCode(code string) error {
 	if len(code) != 1 {
@@ -165,3 +172,118 @@ func (h *NachaBatchHeader) SetBatchNumber(number int) error {
 	h.BatchNumber = util.ToFixedWidthZeroString(strconv.Itoa(number), 7)
 	return nil
 }
+
+// Parse populates the NachaBatchHeader from a 94 character record
+func (h *NachaBatchHeader) Parse(record string) error {
+	if err := checkRecord(record, "5"); err != nil {
+		return err
+	}
+
+	h.Type = record[0:1]
+	h.ServiceClassCode = record[1:4]
+	h.CompanyName = record[4:20]
+	h.CompanyDiscretionaryData = record[20:40]
+	h.CompanyIdentification = record[40:50]
+	h.StandardEntryClassCode = record[50:53]
+	h.CompanyEntryDescription = record[53:63]
+	h.CompanyDescriptiveDate = record[63:69]
+	h.EffectiveEntryDate = record[69:75]
+	h.SettlementDateJulian = record[75:78]
+	h.OriginatorStatusCode = record[78:79]
+	h.ODFIIdentification = record[79:87]
+	h.BatchNumber = record[87:94]
+	return nil
+}
+
+// String returns the NachaBatchHeader as a 94 character record
+func (h *NachaBatchHeader) String() string {
+	return h.Type + h.ServiceClassCode + h.CompanyName +
+		h.CompanyDiscretionaryData + h.CompanyIdentification +
+		h.StandardEntryClassCode + h.CompanyEntryDescription + h.CompanyDescriptiveDate +
+		h.EffectiveEntryDate + h.SettlementDateJulian + h.OriginatorStatusCode +
+		h.ODFIIdentification + h.BatchNumber
+}
+
+// ServiceClassCodeValue returns the ServiceClassCode as an int
+func (h *NachaBatchHeader) ServiceClassCodeValue() (int, error) {
+	return parseIntField("ServiceClassCode", h.ServiceClassCode)
+}
+
+// DescriptiveDate returns the CompanyDescriptiveDate as a time.Time.
+// A blank CompanyDescriptiveDate returns the zero time.
+func (h *NachaBatchHeader) DescriptiveDate() (time.Time, error) {
+	if strings.TrimSpace(h.CompanyDescriptiveDate) == "" {
+		return time.Time{}, nil
+	}
+
+	return parseDateField("CompanyDescriptiveDate", h.CompanyDescriptiveDate)
+}
+
+// EffectiveDate returns the EffectiveEntryDate as a time.Time
+func (h *NachaBatchHeader) EffectiveDate() (time.Time, error) {
+	return parseDateField("EffectiveEntryDate", h.EffectiveEntryDate)
+}
+
+// SettlementDate resolves the SettlementDateJulian to a full date.
+// The year is taken from the EffectiveEntryDate, moving to the previous or next year when that gives a closer date,
+// so a settlement day of 002 with an effective entry date of December 31 resolves to January 2 of the next year.
+func (h *NachaBatchHeader) SettlementDate() (time.Time, error) {
+	if strings.TrimSpace(h.SettlementDateJulian) == "" {
+		return time.Time{}, errors.New("SettlementDateJulian is blank")
+	}
+
+	day, err := parseIntField("SettlementDateJulian", h.SettlementDateJulian)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if day < 1 || day > 366 {
+		return time.Time{}, errors.New("SettlementDateJulian must be a day of the year from 001 to 366")
+	}
+
+	effective, err := h.EffectiveDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var settlement time.Time
+	for year := effective.Year() - 1; year <= effective.Year()+1; year++ {
+		date := time.Date(year, time.January, day, 0, 0, 0, 0, time.UTC)
+		if date.Year() != year {
+			continue
+		}
+		if settlement.IsZero() || absDuration(date.Sub(effective)) < absDuration(settlement.Sub(effective)) {
+			settlement = date
+		}
+	}
+	if settlement.IsZero() {
+		return time.Time{}, errors.New("SettlementDateJulian " + h.SettlementDateJulian + " is not a day of the year around the EffectiveEntryDate")
+	}
+
+	return settlement, nil
+}
+
+// absDuration returns the absolute value of d
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+// BatchNumberValue returns the BatchNumber as an int
+func (h *NachaBatchHeader) BatchNumberValue() (int, error) {
+	return parseIntField("BatchNumber", h.BatchNumber)
+}
+
+// RollEffectiveEntryDate moves the EffectiveEntryDate forward to the next banking day
+// when it falls on a weekend or a Federal Reserve holiday
+func (h *NachaBatchHeader) RollEffectiveEntryDate() error {
+	date, err := h.EffectiveDate()
+	if err != nil {
+		return err
+	}
+
+	h.SetEffectiveEntryDate(calendar.NextBankingDay(date))
+	return nil
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettlementDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		effective  time.Time
+		settlement string
+		want       time.Time
+		wantErr    string
+	}{
+		{name: "same year", effective: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), settlement: "289", want: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "next year", effective: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), settlement: "002", want: time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{name: "previous year", effective: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), settlement: "365", want: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", effective: time.Date(2028, 12, 30, 0, 0, 0, 0, time.UTC), settlement: "366", want: time.Date(2028, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "no leap year around", effective: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), settlement: "366", wantErr: "SettlementDateJulian 366 is not a day of the year around the EffectiveEntryDate"},
+		{name: "out of range", effective: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), settlement: "000", wantErr: "SettlementDateJulian must be a day of the year from 001 to 366"},
+		{name: "blank", effective: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), settlement: "   ", wantErr: "SettlementDateJulian is blank"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := NachaBatchHeader{SettlementDateJulian: tt.settlement}
+			header.SetEffectiveEntryDate(tt.effective)
+
+			got, err := header.SettlementDate()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("SettlementDate() = %v, %v, want error %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("SettlementDate() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetSettlementDate(t *testing.T) {
+	header := NachaBatchHeader{}
+	header.SetSettlementDate(time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC))
+	if header.SettlementDateJulian != "002" {
+		t.Errorf("SettlementDateJulian = %q, want \"002\"", header.SettlementDateJulian)
+	}
+}
+
+func TestBatchHeaderGetters(t *testing.T) {
+	header := &NachaBatchHeader{}
+	must(t, header.Parse("5225ABC COMPANY                         1122334455PPDPAYROLL   261015261016   1011000010000007"))
+	malformed := &NachaBatchHeader{ServiceClassCode: "22O", CompanyDescriptiveDate: "261301", EffectiveEntryDate: "", BatchNumber: "      7"}
+
+	runGetterTests(t, []getterTest{
+		{name: "ServiceClassCodeValue", get: func() (any, error) { return header.ServiceClassCodeValue() }, want: 225},
+		{name: "DescriptiveDate", get: func() (any, error) { return header.DescriptiveDate() }, want: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "EffectiveDate", get: func() (any, error) { return header.EffectiveDate() }, want: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "BatchNumberValue", get: func() (any, error) { return header.BatchNumberValue() }, want: 7},
+		{name: "blank DescriptiveDate", get: func() (any, error) { return (&NachaBatchHeader{CompanyDescriptiveDate: "      "}).DescriptiveDate() }, want: time.Time{}},
+		{name: "malformed ServiceClassCode", get: func() (any, error) { return malformed.ServiceClassCodeValue() }, wantErr: "ServiceClassCode must be numeric"},
+		{name: "malformed DescriptiveDate", get: func() (any, error) { return malformed.DescriptiveDate() }, wantErr: "CompanyDescriptiveDate must be a valid date in the YYMMDD format"},
+		{name: "malformed EffectiveDate", get: func() (any, error) { return malformed.EffectiveDate() }, wantErr: "EffectiveEntryDate must be a valid date in the YYMMDD format"},
+		{name: "malformed BatchNumber", get: func() (any, error) { return malformed.BatchNumberValue() }, wantErr: "BatchNumber must be numeric"},
+	})
+}
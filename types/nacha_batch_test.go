@@ -0,0 +1,38 @@
+package types
+
+import "testing"
+
+func TestDeriveServiceClassCode(t *testing.T) {
+	tests := []struct {
+		name             string
+		transactionCodes []int
+		derive           bool
+		want             string
+	}{
+		{name: "credits only", transactionCodes: []int{22, 32}, derive: true, want: "220"},
+		{name: "debits only", transactionCodes: []int{27, 37}, derive: true, want: "225"},
+		{name: "mixed", transactionCodes: []int{22, 27}, derive: true, want: "200"},
+		{name: "credit prenotes", transactionCodes: []int{23, 33}, derive: true, want: "220"},
+		{name: "debit prenotes", transactionCodes: []int{28, 38}, derive: true, want: "225"},
+		{name: "mixed prenotes", transactionCodes: []int{23, 28}, derive: true, want: "200"},
+		{name: "zero dollar credit", transactionCodes: []int{24}, derive: true, want: "220"},
+		{name: "zero dollar debit", transactionCodes: []int{29}, derive: true, want: "225"},
+		{name: "zero dollar and live", transactionCodes: []int{24, 27}, derive: true, want: "200"},
+		{name: "returns", transactionCodes: []int{21, 26}, derive: true, want: "200"},
+		{name: "no entries", derive: true, want: "225"},
+		{name: "not derived", transactionCodes: []int{22}, want: "225"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := addTestBatch(t, newTestFile(t), "PPD", 225, tt.transactionCodes...)
+			batch.DeriveServiceClassCode = tt.derive
+			batch.GenerateBatchControl()
+
+			if batch.Header.ServiceClassCode != tt.want || batch.Control.ServiceClassCode != tt.want {
+				t.Errorf("ServiceClassCode = %q in the header and %q in the control, want %q",
+					batch.Header.ServiceClassCode, batch.Control.ServiceClassCode, tt.want)
+			}
+		})
+	}
+}
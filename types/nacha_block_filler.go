@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"strings"
 )
 
@@ -13,3 +14,23 @@ type NachaBlockFiller struct {
 func (b *NachaBlockFiller) Default() {
 	b.Reserved = strings.Repeat("9", 94)
 }
+
+// IsBlockFiller reports whether the record is a block filler made up entirely of 9s
+func IsBlockFiller(record string) bool {
+	return record == strings.Repeat("9", RecordLength)
+}
+
+// Parse populates the NachaBlockFiller from a 94 character record
+func (b *NachaBlockFiller) Parse(record string) error {
+	if !IsBlockFiller(record) {
+		return errors.New("block filler must be 94 characters of 9s")
+	}
+
+	b.Reserved = record
+	return nil
+}
+
+// String returns the NachaBlockFiller as a 94 character record
+func (b *NachaBlockFiller) String() string {
+	return b.Reserved
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/rashintha/nacha/util"
+)
+
+// CTX entries replace the IndividualName with the Number of Addenda Records (Char Count: 4),
+// the Receiving Company Name (Char Count: 16) and a Reserved field (Char Count: 2 | Value: Blank)
+
+// MaxCTXAddenda is the maximum number of addenda records a CTX entry can carry
+const MaxCTXAddenda = 9999
+
+// ctxIndividualName makes sure the IndividualName holds the CTX layout before one of its parts is changed
+func (e *NachaEntry) ctxIndividualName() {
+	if len(e.IndividualName) != 22 {
+		e.IndividualName = "0000" + util.ToFixedWidthString("", 18, false)
+	}
+}
+
+// SetNumberOfAddendaRecords sets the Number of Addenda Records of a CTX entry
+func (e *NachaEntry) SetNumberOfAddendaRecords(count int) error {
+	if count < 0 || count > MaxCTXAddenda {
+		return errors.New("NumberOfAddendaRecords must be between 0 and 9999")
+	}
+
+	e.ctxIndividualName()
+	e.IndividualName = util.ToFixedWidthZeroString(strconv.Itoa(count), 4) + e.IndividualName[4:]
+	return nil
+}
+
+// NumberOfAddendaRecords returns the Number of Addenda Records of a CTX entry
+func (e *NachaEntry) NumberOfAddendaRecords() (int, error) {
+	if len(e.IndividualName) != 22 {
+		return 0, errors.New("IndividualName must be 22 characters")
+	}
+
+	return parseIntField("NumberOfAddendaRecords", e.IndividualName[:4])
+}
+
+// SetReceivingCompanyName sets the Receiving Company Name of a CTX entry
+// If the name is more than 16 characters, it will be truncated
+func (e *NachaEntry) SetReceivingCompanyName(name string) error {
+	if name == "" {
+		return errors.New("ReceivingCompanyName cannot be empty")
+	}
+
+	e.ctxIndividualName()
+	e.IndividualName = e.IndividualName[:4] + util.ToFixedWidthString(strings.ToUpper(name), 16, false) + e.IndividualName[20:]
+	return nil
+}
+
+// ReceivingCompanyName returns the Receiving Company Name of a CTX entry
+func (e *NachaEntry) ReceivingCompanyName() string {
+	if len(e.IndividualName) != 22 {
+		return ""
+	}
+
+	return strings.TrimSpace(e.IndividualName[4:20])
+}
+
+// SetEDIPayload splits an EDI payload (such as an ANSI ASC X12 820 remittance) across as many addenda
+// records as needed, replacing the existing addenda of the entry. The AddendaSequenceNumber, the
+// Number of Addenda Records and the AddendaRecordIndicator are updated to match.
+// The payload must be printable ASCII, so every addenda record stays 94 characters on one line.
+func (e *NachaEntry) SetEDIPayload(payload string) error {
+	for i := 0; i < len(payload); i++ {
+		if payload[i] < ' ' || payload[i] > '~' {
+			return errors.New("EDI payload must contain printable ASCII characters only")
+		}
+	}
+
+	count := (len(payload) + 79) / 80
+	if count > MaxCTXAddenda {
+		return errors.New("EDI payload cannot span more than 9999 addenda records")
+	}
+
+	addenda := make([]*NachaAddenda, 0, count)
+	for i := range count {
+		a := &NachaAddenda{}
+		a.Default()
+		a.PaymentRelatedInformation = util.ToFixedWidthString(payload[i*80:min((i+1)*80, len(payload))], 80, false)
+		_ = a.SetAddendaSequenceNumber(i + 1)
+		if len(e.TraceNumber) == 15 {
+			a.EntryDetailSequenceNumber = e.TraceNumber[8:]
+		}
+		addenda = append(addenda, a)
+	}
+
+	e.Addenda = addenda
+	e.SetAddendaRecordIndicator(count > 0)
+	return e.SetNumberOfAddendaRecords(count)
+}
+
+// EDIPayload joins the PaymentRelatedInformation of every addenda record of the entry back into a single payload
+func (e *NachaEntry) EDIPayload() string {
+	var sb strings.Builder
+	for _, a := range e.Addenda {
+		sb.WriteString(a.PaymentRelatedInformation)
+	}
+
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// validateCTX checks the CTX layout of an entry and its addenda records
+func validateCTX(v *validator, entry *NachaEntry) {
+	count, err := entry.NumberOfAddendaRecords()
+	if err != nil {
+		v.add("IndividualName", "CTX entries must start with the 4 digit Number of Addenda Records")
+	} else if count != len(entry.Addenda) {
+		v.add("IndividualName", "CTX Number of Addenda Records must be "+strconv.Itoa(len(entry.Addenda))+" to match the addenda records")
+	}
+
+	if entry.ReceivingCompanyName() == "" {
+		v.add("IndividualName", "CTX entries must carry the Receiving Company Name")
+	}
+	if len(entry.Addenda) > MaxCTXAddenda {
+		v.add("Addenda", "CTX entries can have at most 9999 addenda records")
+	}
+
+	for i, a := range entry.Addenda {
+		if seq, err := a.AddendaSequenceNumberValue(); err == nil && seq != i+1 {
+			v.add("Addenda", "CTX addenda records must be numbered 1 to "+strconv.Itoa(len(entry.Addenda))+" in order")
+			break
+		}
+	}
+}
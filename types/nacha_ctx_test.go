@@ -0,0 +1,88 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetEDIPayload(t *testing.T) {
+	payload := "ISA*00*          *00*          *ZZ*ABC COMPANY    *ZZ*BBC COMPANY    *261016*1749*U*00401*000000042*0*P*>~" +
+		"ST*820*0001~BPR*C*100.25*C*ACH*CTX*01*011000015*DA*1122334455~RMR*IV*INV42**100.25~SE*4*0001~"
+
+	file := newTestFile(t)
+	batch := addTestBatch(t, file, "CTX", 220, 22)
+	entry := batch.Entries[0]
+	must(t, entry.SetEDIPayload(payload))
+
+	want := (len(payload) + 79) / 80
+	if len(entry.Addenda) != want {
+		t.Fatalf("SetEDIPayload() added %d addenda records, want %d", len(entry.Addenda), want)
+	}
+	for i, a := range entry.Addenda {
+		if seq, err := a.AddendaSequenceNumberValue(); err != nil || seq != i+1 {
+			t.Errorf("Addenda[%d] AddendaSequenceNumber = %q", i, a.AddendaSequenceNumber)
+		}
+		if a.EntryDetailSequenceNumber != entry.TraceNumber[8:] {
+			t.Errorf("Addenda[%d] EntryDetailSequenceNumber = %q, want %q", i, a.EntryDetailSequenceNumber, entry.TraceNumber[8:])
+		}
+		if len(a.String()) != 94 {
+			t.Errorf("Addenda[%d] is %d characters, want 94", i, len(a.String()))
+		}
+	}
+	if count, err := entry.NumberOfAddendaRecords(); err != nil || count != want || !entry.HasAddenda() {
+		t.Errorf("NumberOfAddendaRecords() = %d, %v, indicator %q", count, err, entry.AddendaRecordIndicator)
+	}
+	if entry.ReceivingCompanyName() != "BBC COMPANY" {
+		t.Errorf("ReceivingCompanyName() = %q, want %q", entry.ReceivingCompanyName(), "BBC COMPANY")
+	}
+	if got := entry.EDIPayload(); got != payload {
+		t.Errorf("EDIPayload() = %q, want %q", got, payload)
+	}
+
+	must(t, file.GenerateFile())
+	if err := file.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	must(t, entry.SetEDIPayload(""))
+	if count, _ := entry.NumberOfAddendaRecords(); len(entry.Addenda) != 0 || count != 0 || entry.HasAddenda() {
+		t.Errorf("SetEDIPayload(\"\") left %d addenda records, count %d, indicator %q", len(entry.Addenda), count, entry.AddendaRecordIndicator)
+	}
+}
+
+func TestSetEDIPayloadLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string
+	}{
+		{name: "9999 addenda records", payload: strings.Repeat("X", MaxCTXAddenda*80)},
+		{name: "10000 addenda records", payload: strings.Repeat("X", MaxCTXAddenda*80+1), wantErr: "EDI payload cannot span more than 9999 addenda records"},
+		{name: "non-ASCII character", payload: "RMR*IV*INVOICE-CAFÉ~", wantErr: "EDI payload must contain printable ASCII characters only"},
+		{name: "line feed", payload: "ST*820*0001~\nSE*2*0001~", wantErr: "EDI payload must contain printable ASCII characters only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := addTestBatch(t, newTestFile(t), "CTX", 220, 22).Entries[0]
+			must(t, entry.SetEDIPayload("RMR*IV*INV42**100.25~"))
+
+			err := entry.SetEDIPayload(tt.payload)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("SetEDIPayload() error = %v, want %q", err, tt.wantErr)
+				}
+				if entry.EDIPayload() != "RMR*IV*INV42**100.25~" {
+					t.Errorf("SetEDIPayload() replaced the addenda records after failing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetEDIPayload() error = %v", err)
+			}
+			if count, _ := entry.NumberOfAddendaRecords(); count != MaxCTXAddenda || len(entry.Addenda) != MaxCTXAddenda {
+				t.Errorf("SetEDIPayload() = %d addenda records, count %d, want %d", len(entry.Addenda), count, MaxCTXAddenda)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Encoder writes NACHA records to an output stream through a buffer,
+// one record per line, without building the whole file in memory
+type Encoder struct {
+	w     *bufio.Writer
+	count *countingWriter
+}
+
+// NewEncoder returns a new Encoder that writes to w
+func NewEncoder(w io.Writer) *Encoder {
+	count := &countingWriter{w: w}
+	return &Encoder{w: bufio.NewWriter(count), count: count}
+}
+
+// Encode writes every record of the NachaFile and flushes the output
+func (e *Encoder) Encode(f *NachaFile) error {
+	if err := e.EncodeRecord(&f.Header); err != nil {
+		return err
+	}
+
+	for _, batch := range f.Batches {
+		if err := e.EncodeRecord(&batch.Header); err != nil {
+			return err
+		}
+
+		for _, entry := range batch.Entries {
+			if err := e.EncodeRecord(entry); err != nil {
+				return err
+			}
+
+			for _, addenda := range entry.Addenda {
+				if err := e.EncodeRecord(addenda); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := e.EncodeRecord(&batch.Control); err != nil {
+			return err
+		}
+	}
+
+	if err := e.EncodeRecord(&f.Control); err != nil {
+		return err
+	}
+
+	for _, filler := range f.BlockFillers {
+		if err := e.EncodeRecord(filler); err != nil {
+			return err
+		}
+	}
+
+	return e.Flush()
+}
+
+// EncodeRecord writes a single record followed by a line feed.
+// The output is buffered, so Flush must be called after the last record.
+func (e *Encoder) EncodeRecord(record fmt.Stringer) error {
+	if _, err := e.w.WriteString(record.String()); err != nil {
+		return err
+	}
+
+	return e.w.WriteByte('\n')
+}
+
+// Flush writes any buffered records to the underlying writer
+func (e *Encoder) Flush() error {
+	return e.w.Flush()
+}
+
+// countingWriter counts the bytes written to the underlying writer
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes p to the underlying writer and adds the written bytes to the count
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
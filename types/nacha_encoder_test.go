@@ -0,0 +1,107 @@
+package types_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rashintha/nacha"
+	"github.com/rashintha/nacha/types"
+)
+
+// newEncoderTestFile builds a generated file with a single debit entry, 5 records padded with 5 block fillers
+func newEncoderTestFile(t *testing.T) *types.NachaFile {
+	t.Helper()
+
+	file := nacha.NewFile()
+	check(t, file.Header.SetImmediateDestination("021000021"))
+	check(t, file.Header.SetImmediateOrigin("011000015"))
+	check(t, file.Header.SetImmediateDestinationName("Destination Bank"))
+	check(t, file.Header.SetImmediateOriginName("Origin Bank"))
+
+	batch := file.NewBatch()
+	check(t, batch.Header.SetServiceClassCode(225))
+	check(t, batch.Header.SetCompanyName("ABC Company"))
+	check(t, batch.Header.SetCompanyIdentification("1122334455"))
+	check(t, batch.Header.SetStandardEntryClassCode("PPD"))
+	check(t, batch.Header.SetCompanyEntryDescription("Payroll"))
+	batch.Header.SetEffectiveEntryDate(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	check(t, batch.Header.SetODFIIdentification("01100001"))
+	check(t, batch.Header.SetBatchNumber(1))
+
+	entry := batch.AddEntry()
+	check(t, entry.SetTransactionCode(27))
+	check(t, entry.SetReceivingDFI("021000021"))
+	check(t, entry.SetDFIAccountNumber("29079117"))
+	check(t, entry.SetAmount(types.Dollars(1364, 0)))
+	check(t, entry.SetIndividualIDNumber("392344"))
+	check(t, entry.SetIndividualName("BBC Company"))
+	check(t, entry.SetTraceNumber("01100001", 1))
+
+	check(t, file.GenerateFile())
+	return file
+}
+
+// check fails the test when err is not nil
+func check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	file := newEncoderTestFile(t)
+
+	var buf bytes.Buffer
+	n, err := file.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if n != int64(buf.Len()) || n != 10*(types.RecordLength+1) {
+		t.Errorf("WriteTo() = %d bytes, wrote %d, want %d", n, buf.Len(), 10*(types.RecordLength+1))
+	}
+	if buf.String() != file.String() {
+		t.Errorf("WriteTo() and String() differ")
+	}
+
+	parsed, err := nacha.Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.String() != file.String() {
+		t.Errorf("Parse(WriteTo()) =\n%s\nwant\n%s", parsed.String(), file.String())
+	}
+}
+
+func TestEncodeBlockFillers(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(newEncoderTestFile(t).String(), "\n"), "\n")
+
+	if len(lines) != 10 {
+		t.Fatalf("file has %d records, want a block of 10", len(lines))
+	}
+	for i, line := range lines {
+		if len(line) != types.RecordLength {
+			t.Errorf("record %d is %d characters, want %d", i+1, len(line), types.RecordLength)
+		}
+		if filler := i >= 5; filler != (line == strings.Repeat("9", types.RecordLength)) {
+			t.Errorf("record %d = %q, block filler %v", i+1, line, filler)
+		}
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+// Write returns an error
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestEncodeWriteError(t *testing.T) {
+	if _, err := newEncoderTestFile(t).WriteTo(failingWriter{}); err == nil || err.Error() != "disk full" {
+		t.Errorf("WriteTo() error = %v, want \"disk full\"", err)
+	}
+}
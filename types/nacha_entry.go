@@ -2,10 +2,10 @@ package types
 
 import (
 	"errors"
-	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/rashintha/nacha/routing"
 	"github.com/rashintha/nacha/util"
 )
 
@@ -14,8 +14,11 @@ type NachaEntry struct {
 	Type string // Char Count: 1 | Fixed Value: 6
 
 	// Char Count: 2 | Values:
-	// Checking Accounts - 22 (Credit), 23 (Prenote Credit), 27 (Debits), 28 (Prenote Debit)
-	// Savings Accounts - 32 (Credit), 33 (Prenote Credit), 37 (Debits), 38 (Prenote Debit)
+	// Checking Accounts - 21 (Return Credit), 22 (Credit), 23 (Prenote Credit), 24 (Zero Dollar Credit),
+	// 26 (Return Debit), 27 (Debits), 28 (Prenote Debit), 29 (Zero Dollar Debit)
+	// Savings Accounts - 31 to 34 and 36 to 39, as for Checking Accounts
+	// General Ledger Accounts - 41 to 44 and 46 to 48, as for Checking Accounts
+	// Loan Accounts - 51 (Return Credit), 52 (Credit), 53 (Prenote Credit), 54 (Zero Dollar Credit), 55 (Debit, Reversals Only), 56 (Return Debit)
 	TransactionCode            string
 	ReceivingDFIIdentification string // Char Count: 8 | value: First 8 digits of the Receiving DFI Routing Number
 	CheckDigit                 string // Char Count: 1 | Value: Last digit of the Receiving DFI Routing Number
@@ -23,13 +26,15 @@ type NachaEntry struct {
 	Amount                     string // Char Count: 10 | Value: Amount of the Entry
 
 	IndividualIDNumber string // Char Count: 15 | Value: Individual ID Number (Employee Number etc.)
-	IndividualName     string // Char Count: 22 | Value: Individual Name
+	IndividualName     string // Char Count: 22 | Value: Individual Name | CTX: Number of Addenda Records and Receiving Company Name
 
-	DiscretionaryData      string // Char Count: 2 | Optional
+	DiscretionaryData      string // Char Count: 2 | Optional | WEB & TEL: Payment Type Code (R - Recurring, S - Single Entry)
 	AddendaRecordIndicator string // Char Count: 1 | Value: 0 - No Addenda Record, 1 - Addenda Record
 	TraceNumber            string // Char Count: 15 | Value: First 8 digits of the ODFI Routing Number plus Entry Detail Sequence Number
 
 	Addenda []*NachaAddenda // Optional
+
+	offset bool // Set on entries added by NachaBatch.GenerateOffset
 }
 
 // Default sets the default values for the NachaEntry
@@ -41,8 +46,8 @@ func (e *NachaEntry) Default() {
 
 // SetTransactionCode sets the TransactionCode
 func (e *NachaEntry) SetTransactionCode(code int) error {
-	if code != 22 && code != 23 && code != 27 && code != 28 && code != 32 && code != 33 && code != 37 && code != 38 {
-		return errors.New("TransactionCode must be 22, 23, 27, 28, 32, 33, 37, or 38")
+	if _, ok := LookupTransactionCode(code); !ok {
+		return errors.New("TransactionCode must be one of " + strings.Join(transactionCodeList(), ", "))
 	}
 
 	e.TransactionCode = strconv.Itoa(code)
@@ -69,6 +74,19 @@ func (e *NachaEntry) SetCheckDigit(digit string) error {
 	return nil
 }
 
+// SetReceivingDFI validates a 9 digit routing number and splits it into
+// the ReceivingDFIIdentification and the CheckDigit
+func (e *NachaEntry) SetReceivingDFI(routingNumber string) error {
+	id, digit, err := routing.Split(routingNumber)
+	if err != nil {
+		return err
+	}
+
+	e.ReceivingDFIIdentification = id
+	e.CheckDigit = digit
+	return nil
+}
+
 // SetDFIAccountNumber sets the DFIAccountNumber
 func (e *NachaEntry) SetDFIAccountNumber(number string) error {
 	if number == "" {
@@ -80,15 +98,28 @@ func (e *NachaEntry) SetDFIAccountNumber(number string) error {
 }
 
 // SetAmount sets the Amount
-func (e *NachaEntry) SetAmount(amount float64) error {
-	if amount < 1 {
+// Prenote and zero dollar entries only accept a zero amount, other entries need an amount greater than 0
+func (e *NachaEntry) SetAmount(amount Amount) error {
+	if e.carriesZeroAmount() {
+		if amount != 0 {
+			return errors.New("Amount must be 0 for prenote and zero dollar entries")
+		}
+	} else if amount <= 0 {
 		return errors.New("Amount must be greater than 0")
 	}
+	if amount > 9999999999 {
+		return errors.New("Amount must be less than or equal to 99999999.99")
+	}
 
-	e.Amount = util.ToFixedWidthZeroString(fmt.Sprintf("%d", int(amount*100)), 10)
+	e.Amount = amount.format(10)
 	return nil
 }
 
+// AmountCents returns the Amount as an exact number of cents
+func (e *NachaEntry) AmountCents() (Amount, error) {
+	return parseAmountField("Amount", e.Amount)
+}
+
 // SetIndividualIDNumber sets the IndividualIDNumber
 func (e *NachaEntry) SetIndividualIDNumber(id string) error {
 	if id == "" {
@@ -124,6 +155,21 @@ func (e *NachaEntry) SetDiscretionaryDataToDefault() {
 	e.DiscretionaryData = util.ToFixedWidthString("", 2, false)
 }
 
+// SetPaymentTypeCode sets the Payment Type Code carried in the DiscretionaryData of WEB and TEL entries
+func (e *NachaEntry) SetPaymentTypeCode(code string) error {
+	if code != "R" && code != "S" {
+		return errors.New("PaymentTypeCode must be R or S")
+	}
+
+	e.DiscretionaryData = util.ToFixedWidthString(code, 2, false)
+	return nil
+}
+
+// PaymentTypeCode returns the Payment Type Code carried in the DiscretionaryData
+func (e *NachaEntry) PaymentTypeCode() string {
+	return strings.TrimSpace(e.DiscretionaryData)
+}
+
 // SetAddendaRecordIndicator sets the AddendaRecordIndicator
 func (e *NachaEntry) SetAddendaRecordIndicator(indicator bool) {
 
@@ -135,6 +181,7 @@ func (e *NachaEntry) SetAddendaRecordIndicator(indicator bool) {
 }
 
 // SetTraceNumber sets the TraceNumber
+// The EntryDetailSequenceNumber of the addenda records is updated to match
 func (e *NachaEntry) SetTraceNumber(odfiId string, number int) error {
 	if odfiId == "" {
 		return errors.New("ODFIId cannot be empty")
@@ -147,15 +194,134 @@ func (e *NachaEntry) SetTraceNumber(odfiId string, number int) error {
 	}
 
 	e.TraceNumber = odfiId + util.ToFixedWidthZeroString(strconv.Itoa(number), 7)
+	e.linkAddenda()
 	return nil
 }
 
 // NewAddenda creates a new NachaAddenda and adds it to the Addenda slice
+// The addenda is numbered after the existing addenda and linked to the TraceNumber when it is set
 func (e *NachaEntry) NewAddenda() *NachaAddenda {
 	addenda := &NachaAddenda{}
 	addenda.Default()
-	addenda.EntryDetailSequenceNumber = e.TraceNumber[len(e.TraceNumber)-7:]
+	_ = addenda.SetAddendaSequenceNumber(len(e.Addenda) + 1)
+	if len(e.TraceNumber) == 15 {
+		addenda.EntryDetailSequenceNumber = e.TraceNumber[8:]
+	}
 	e.Addenda = append(e.Addenda, addenda)
 	e.AddendaRecordIndicator = "1"
 	return addenda
 }
+
+// RenumberAddenda numbers the addenda records of the entry from 1 in their current order, links them
+// to the entry TraceNumber and sets the AddendaRecordIndicator.
+// IAT addenda 17 and 18 are numbered separately, IAT addenda 10 to 16 have no AddendaSequenceNumber,
+// and return and notification of change addenda carry the full TraceNumber instead.
+func (e *NachaEntry) RenumberAddenda() {
+	sequences := make(map[string]int)
+	for _, addenda := range e.Addenda {
+		if addenda.isReturn() || addenda.isNOC() {
+			continue
+		}
+
+		if addenda.hasAddendaSequenceNumber() {
+			sequences[addenda.AddendaTypeCode]++
+			_ = addenda.SetAddendaSequenceNumber(sequences[addenda.AddendaTypeCode])
+		} else {
+			addenda.AddendaSequenceNumber = util.ToFixedWidthString("", 4, false)
+		}
+	}
+
+	e.linkAddenda()
+	e.SetAddendaRecordIndicator(len(e.Addenda) > 0)
+}
+
+// linkAddenda copies the TraceNumber to the addenda records of the entry
+func (e *NachaEntry) linkAddenda() {
+	if len(e.TraceNumber) != 15 {
+		return
+	}
+
+	for _, addenda := range e.Addenda {
+		if addenda.isReturn() || addenda.isNOC() {
+			addenda.SetAddendaTraceNumber(e.TraceNumber)
+		} else {
+			addenda.EntryDetailSequenceNumber = e.TraceNumber[8:]
+		}
+	}
+}
+
+// clone returns a copy of the entry with copies of its addenda records
+func (e *NachaEntry) clone() *NachaEntry {
+	entry := *e
+	entry.Addenda = nil
+	for _, addenda := range e.Addenda {
+		copied := *addenda
+		entry.Addenda = append(entry.Addenda, &copied)
+	}
+
+	return &entry
+}
+
+// Parse populates the NachaEntry from a 94 character record.
+// Addenda records are not part of the entry record and must be parsed separately.
+func (e *NachaEntry) Parse(record string) error {
+	if err := checkRecord(record, "6"); err != nil {
+		return err
+	}
+
+	e.Type = record[0:1]
+	e.TransactionCode = record[1:3]
+	e.ReceivingDFIIdentification = record[3:11]
+	e.CheckDigit = record[11:12]
+	e.DFIAccountNumber = record[12:29]
+	e.Amount = record[29:39]
+	e.IndividualIDNumber = record[39:54]
+	e.IndividualName = record[54:76]
+	e.DiscretionaryData = record[76:78]
+	e.AddendaRecordIndicator = record[78:79]
+	e.TraceNumber = record[79:94]
+	return nil
+}
+
+// String returns the NachaEntry as a 94 character record, without its addenda
+func (e *NachaEntry) String() string {
+	return e.Type + e.TransactionCode + e.ReceivingDFIIdentification + e.CheckDigit +
+		e.DFIAccountNumber + e.Amount + e.IndividualIDNumber + e.IndividualName +
+		e.DiscretionaryData + e.AddendaRecordIndicator + e.TraceNumber
+}
+
+// TransactionCodeValue returns the TransactionCode as an int
+func (e *NachaEntry) TransactionCodeValue() (int, error) {
+	return parseIntField("TransactionCode", e.TransactionCode)
+}
+
+// RoutingNumber returns the 9 digit routing number made of the ReceivingDFIIdentification and the CheckDigit
+func (e *NachaEntry) RoutingNumber() string {
+	return e.ReceivingDFIIdentification + e.CheckDigit
+}
+
+// IsDebit reports whether the TransactionCode debits the receiver's account
+func (e *NachaEntry) IsDebit() bool {
+	info, ok := transactionCodes[e.TransactionCode]
+	return ok && info.Debit
+}
+
+// IsCredit reports whether the TransactionCode credits the receiver's account
+func (e *NachaEntry) IsCredit() bool {
+	info, ok := transactionCodes[e.TransactionCode]
+	return ok && !info.Debit
+}
+
+// HasAddenda reports whether the AddendaRecordIndicator is set
+func (e *NachaEntry) HasAddenda() bool {
+	return e.AddendaRecordIndicator == "1"
+}
+
+// TraceSequence returns the Entry Detail Sequence Number held in the last 7 digits of the TraceNumber
+func (e *NachaEntry) TraceSequence() (int, error) {
+	if len(e.TraceNumber) != 15 {
+		return 0, errors.New("TraceNumber must be 15 characters")
+	}
+
+	return parseIntField("TraceNumber", e.TraceNumber[8:])
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// getterTest is a typed getter call with its expected value or error
+type getterTest struct {
+	name    string
+	get     func() (any, error)
+	want    any
+	wantErr string
+}
+
+// runGetterTests runs every getter test
+func runGetterTests(t *testing.T, tests []getterTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryGetters(t *testing.T) {
+	entry := &NachaEntry{}
+	must(t, entry.Parse("62702100002129079117         0000136400392344         BBC COMPANY            S1011000010000042"))
+	malformed := &NachaEntry{TransactionCode: "2A", Amount: "00000013 4", TraceNumber: "01100001000004X"}
+
+	runGetterTests(t, []getterTest{
+		{name: "TransactionCodeValue", get: func() (any, error) { return entry.TransactionCodeValue() }, want: 27},
+		{name: "AmountCents", get: func() (any, error) { return entry.AmountCents() }, want: Dollars(1364, 0)},
+		{name: "TraceSequence", get: func() (any, error) { return entry.TraceSequence() }, want: 42},
+		{name: "RoutingNumber", get: func() (any, error) { return entry.RoutingNumber(), nil }, want: "021000021"},
+		{name: "PaymentTypeCode", get: func() (any, error) { return entry.PaymentTypeCode(), nil }, want: "S"},
+		{name: "HasAddenda", get: func() (any, error) { return entry.HasAddenda(), nil }, want: true},
+		{name: "IsDebit", get: func() (any, error) { return entry.IsDebit() && !entry.IsCredit(), nil }, want: true},
+		{name: "malformed TransactionCode", get: func() (any, error) { return malformed.TransactionCodeValue() }, wantErr: "TransactionCode must be numeric"},
+		{name: "malformed Amount", get: func() (any, error) { return malformed.AmountCents() }, wantErr: "Amount must be numeric"},
+		{name: "malformed TraceNumber", get: func() (any, error) { return malformed.TraceSequence() }, wantErr: "TraceNumber must be numeric"},
+		{name: "short TraceNumber", get: func() (any, error) { return (&NachaEntry{TraceNumber: "0110"}).TraceSequence() }, wantErr: "TraceNumber must be 15 characters"},
+		{name: "unknown TransactionCode", get: func() (any, error) { return malformed.IsDebit() || malformed.IsCredit(), nil }, want: false},
+	})
+}
+
+func TestRenumberAddenda(t *testing.T) {
+	entry := addTestBatch(t, newTestFile(t), "CTX", 220, 22).Entries[0]
+	for _, info := range []string{"First", "Second", "Third", "Fourth"} {
+		entry.NewAddenda().SetPaymentRelatedInformation(info)
+	}
+
+	// Remove the second addenda record, move the last one to the front and change the TraceNumber
+	entry.Addenda = append(entry.Addenda[:1], entry.Addenda[2:]...)
+	entry.Addenda = append(entry.Addenda[2:], entry.Addenda[:2]...)
+	entry.TraceNumber = testODFI + "0000042"
+	entry.RenumberAddenda()
+
+	for i, want := range []string{"FOURTH", "FIRST", "THIRD"} {
+		a := entry.Addenda[i]
+		if strings.TrimSpace(a.PaymentRelatedInformation) != want || a.AddendaSequenceNumber != fmt.Sprintf("%04d", i+1) || a.EntryDetailSequenceNumber != "0000042" {
+			t.Errorf("Addenda[%d] = %q, sequence %q, entry detail %q", i, a.PaymentRelatedInformation, a.AddendaSequenceNumber, a.EntryDetailSequenceNumber)
+		}
+	}
+	if !entry.HasAddenda() {
+		t.Errorf("RenumberAddenda() AddendaRecordIndicator = %q, want 1", entry.AddendaRecordIndicator)
+	}
+
+	entry.Addenda = nil
+	entry.RenumberAddenda()
+	if entry.HasAddenda() {
+		t.Errorf("RenumberAddenda() without addenda AddendaRecordIndicator = %q, want 0", entry.AddendaRecordIndicator)
+	}
+}
+
+func TestRenumberAddendaIAT(t *testing.T) {
+	entry := addIATTestBatch(t, newTestFile(t)).Entries[0]
+	for _, info := range []string{"Invoice 42", "Invoice 43"} {
+		if _, err := entry.AddIATAddenda(IATAddenda17{PaymentRelatedInformation: info}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 2 {
+		if _, err := entry.AddIATAddenda(IATAddenda18{
+			ForeignCorrespondentBankName:              "Correspondent Bank",
+			ForeignCorrespondentBankIDNumberQualifier: "02",
+			ForeignCorrespondentBankIDNumber:          "CORRGB2L",
+			ForeignCorrespondentBankBranchCountryCode: "GB",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Remove the first addenda 17 and the first addenda 18
+	entry.Addenda = append(entry.Addenda[:7], entry.Addenda[8], entry.Addenda[10])
+	entry.RenumberAddenda()
+
+	for i, want := range []string{"    ", "    ", "    ", "    ", "    ", "    ", "    ", "0001", "0001"} {
+		if a := entry.Addenda[i]; a.AddendaSequenceNumber != want || a.EntryDetailSequenceNumber != "0000001" {
+			t.Errorf("Addenda[%d] %s sequence %q, want %q", i, a.AddendaTypeCode, a.AddendaSequenceNumber, want)
+		}
+	}
+}
+
+func TestRenumberAddendaReturn(t *testing.T) {
+	file, _ := newReturnTestFile(t, "R01", time.Time{})
+	entry := file.Batches[0].Entries[0]
+	must(t, entry.SetTraceNumber("02100002", 42))
+	entry.RenumberAddenda()
+
+	if a := entry.Addenda[0]; a.AddendaTraceNumber() != "021000020000042" || a.ReturnReasonCode() != "R01" {
+		t.Errorf("RenumberAddenda() return addenda trace %q, reason %q", a.AddendaTraceNumber(), a.ReturnReasonCode())
+	}
+}
@@ -1,9 +1,9 @@
 package types
 
 import (
-	"fmt"
-	"math"
+	"io"
 	"strconv"
+	"strings"
 
 	"github.com/rashintha/nacha/util"
 )
@@ -14,6 +14,11 @@ type NachaFile struct {
 	Batches      []*NachaBatch
 	Control      NachaFileControl
 	BlockFillers []*NachaBlockFiller
+
+	Offset    *NachaOffset    // Optional | Settlement account used to balance every batch without its own Offset
+	Sequencer *NachaSequencer // Optional | Assigns batch and trace numbers when the file is generated, keeping those already assigned
+
+	RollEffectiveDates bool // Optional | Moves every EffectiveEntryDate forward to a banking day when the file is generated
 }
 
 // NewBatch creates a new NachaBatch and appends it to the file's Batches'
@@ -32,102 +37,99 @@ func (f *NachaFile) NewBlockFiller() {
 	f.BlockFillers = append(f.BlockFillers, filler)
 }
 
-// GenerateFileControl generates the FileControl
+// GenerateFileControl generates the FileControl and the BlockFillers
+// The BlockFillers are rebuilt on every call, so the file is always padded to exactly a multiple of 10 records
 func (f *NachaFile) GenerateFileControl() {
 	f.Control.BatchCount = util.ToFixedWidthZeroString(strconv.Itoa(len(f.Batches)), 6)
 
-	blockCount := float64(2)
+	recordCount := 2
 	entryAddendaCount := 0
 	entryHashTotal := int64(0)
-	totalDebits := int64(0)
-	totalCredits := int64(0)
+	totalDebits := Amount(0)
+	totalCredits := Amount(0)
 
 	for _, batch := range f.Batches {
-		blockCount += float64(2 + len(batch.Entries))
+		recordCount += 2 + len(batch.Entries)
 		entryAddendaCount += len(batch.Entries)
 
 		entryHash, _ := strconv.ParseInt(batch.Control.EntryHash, 10, 64)
 		entryHashTotal += entryHash
 
 		for _, entry := range batch.Entries {
-			blockCount += float64(len(entry.Addenda))
+			recordCount += len(entry.Addenda)
 			entryAddendaCount += len(entry.Addenda)
 		}
 
-		debitAmount, _ := strconv.ParseInt(batch.Control.TotalDebits, 10, 64)
+		debitAmount, _ := batch.Control.TotalDebitsCents()
 		totalDebits += debitAmount
 
-		creditAmount, _ := strconv.ParseInt(batch.Control.TotalCredits, 10, 64)
+		creditAmount, _ := batch.Control.TotalCreditsCents()
 		totalCredits += creditAmount
 	}
 
-	f.Control.BlockCount = util.ToFixedWidthZeroString(strconv.Itoa(int(math.Ceil(blockCount/10))), 6)
+	fillerCount := (10 - recordCount%10) % 10
+
+	f.Control.BlockCount = util.ToFixedWidthZeroString(strconv.Itoa((recordCount+fillerCount)/10), 6)
 	f.Control.EntryAddendaCount = util.ToFixedWidthZeroString(strconv.Itoa(entryAddendaCount), 8)
-	f.Control.EntryHash = util.ToFixedWidthZeroString(strconv.Itoa(int(entryHashTotal)), 10)
-	f.Control.TotalDebits = util.ToFixedWidthZeroString(strconv.Itoa(int(totalDebits)), 12)
-	f.Control.TotalCredits = util.ToFixedWidthZeroString(strconv.Itoa(int(totalCredits)), 12)
+	f.Control.EntryHash = formatEntryHash(entryHashTotal)
+	f.Control.TotalDebits = totalDebits.format(12)
+	f.Control.TotalCredits = totalCredits.format(12)
 
-	for range 10 - (int(blockCount) % 10) {
+	f.BlockFillers = nil
+	for range fillerCount {
 		f.NewBlockFiller()
 	}
 }
 
 // GenerateFile generates the NACHA file content
-func (f *NachaFile) GenerateFile() {
+// Effective entry dates are moved forward to banking days when RollEffectiveDates is set,
+// batches are balanced with an offset entry when the batch or the file has an Offset,
+// batch and trace numbers are assigned when the file has a Sequencer, and the addenda records of every entry are
+// renumbered and linked to the final trace numbers
+func (f *NachaFile) GenerateFile() error {
 	for _, batch := range f.Batches {
-		batch.GenerateBatchControl()
-	}
+		if f.RollEffectiveDates {
+			if err := batch.Header.RollEffectiveEntryDate(); err != nil {
+				return err
+			}
+		}
 
-	f.GenerateFileControl()
-}
+		offset := batch.Offset
+		if offset == nil {
+			offset = f.Offset
+		}
+		if err := batch.GenerateOffset(offset); err != nil {
+			return err
+		}
+	}
 
-// String returns the NACHA file as a string
-func (f *NachaFile) String() string {
-	// File Header
-	nachaString := fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s%s%s%s\n",
-		f.Header.Type, f.Header.PriorityCode, f.Header.ImmediateDestination, f.Header.ImmediateOrigin,
-		f.Header.FileCreationDate, f.Header.FileCreationTime, f.Header.FileIDModifier,
-		f.Header.RecordSize, f.Header.BlockingFactor, f.Header.FormatCode,
-		f.Header.ImmediateDestinationName, f.Header.ImmediateOriginName, f.Header.ReferenceCode)
+	if f.Sequencer != nil {
+		if err := f.Sequencer.Assign(f); err != nil {
+			return err
+		}
+	}
 
 	for _, batch := range f.Batches {
-		// Batch Header
-		nachaString += fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s%s%s%s\n",
-			batch.Header.Type, batch.Header.ServiceClassCode, batch.Header.CompanyName,
-			batch.Header.CompanyDiscretionaryData, batch.Header.CompanyIdentification,
-			batch.Header.StandardEntryClassCode, batch.Header.CompanyEntryDescription, batch.Header.CompanyDescriptiveDate,
-			batch.Header.EffectiveEntryDate, batch.Header.SettlementDateJulian, batch.Header.OriginatorStatusCode,
-			batch.Header.ODFIIdentification, batch.Header.BatchNumber)
-
-		for _, entry := range batch.Entries {
-			nachaString += fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s%s\n",
-				entry.Type, entry.TransactionCode, entry.ReceivingDFIIdentification, entry.CheckDigit,
-				entry.DFIAccountNumber, entry.Amount, entry.IndividualIDNumber, entry.IndividualName,
-				entry.DiscretionaryData, entry.AddendaRecordIndicator, entry.TraceNumber)
-
-			for _, addenda := range entry.Addenda {
-				nachaString += fmt.Sprintf("%s%s%s%s%s\n",
-					addenda.Type, addenda.AddendaTypeCode, addenda.PaymentRelatedInformation,
-					addenda.AddendaSequenceNumber, addenda.EntryDetailSequenceNumber)
-			}
+		if err := batch.GenerateAddenda(); err != nil {
+			return err
 		}
-
-		nachaString += fmt.Sprintf("%s%s%s%s%s%s%s%s%s%s%s\n",
-			batch.Control.Type, batch.Control.ServiceClassCode, batch.Control.EntryAddendaCount,
-			batch.Control.EntryHash, batch.Control.TotalDebits, batch.Control.TotalCredits,
-			batch.Control.CompanyIdentification, batch.Control.MessageAuthenticationCode, batch.Control.Reserved,
-			batch.Control.ODFIIdentification, batch.Control.BatchNumber)
+		batch.GenerateBatchControl()
 	}
 
-	// File Control
-	nachaString += fmt.Sprintf("%s%s%s%s%s%s%s%s\n",
-		f.Control.Type, f.Control.BatchCount, f.Control.BlockCount, f.Control.EntryAddendaCount,
-		f.Control.EntryHash, f.Control.TotalDebits, f.Control.TotalCredits, f.Control.Reserved)
+	f.GenerateFileControl()
+	return nil
+}
 
-	// Block Fillers
-	for _, filler := range f.BlockFillers {
-		nachaString += fmt.Sprintf("%s\n", filler.Reserved)
-	}
+// WriteTo writes the NACHA file to w and returns the number of bytes written
+func (f *NachaFile) WriteTo(w io.Writer) (int64, error) {
+	encoder := NewEncoder(w)
+	err := encoder.Encode(f)
+	return encoder.count.n, err
+}
 
-	return nachaString
+// String returns the NACHA file as a string
+func (f *NachaFile) String() string {
+	var sb strings.Builder
+	_, _ = f.WriteTo(&sb)
+	return sb.String()
 }
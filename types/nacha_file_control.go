@@ -73,27 +73,80 @@ func (f *NachaFileControl) SetEntryAddendaCount(count int) error {
 }
 
 // SetTotalDebits sets the TotalDebits
-func (f *NachaFileControl) SetTotalDebits(amount float64) error {
+func (f *NachaFileControl) SetTotalDebits(amount Amount) error {
 	if amount < 0 {
 		return errors.New("TotalDebits must be greater than or equal to 0")
 	}
-	if amount > 9999999999.99 {
+	if amount > 999999999999 {
 		return errors.New("TotalDebits must be less than or equal to 9999999999.99")
 	}
 
-	f.TotalDebits = util.ToFixedWidthZeroString(strconv.Itoa(int(amount*100)), 12)
+	f.TotalDebits = amount.format(12)
 	return nil
 }
 
+// TotalDebitsCents returns the TotalDebits as an exact number of cents
+func (f *NachaFileControl) TotalDebitsCents() (Amount, error) {
+	return parseAmountField("TotalDebits", f.TotalDebits)
+}
+
 // SetTotalCredits sets the TotalCredits
-func (f *NachaFileControl) SetTotalCredits(amount float64) error {
+func (f *NachaFileControl) SetTotalCredits(amount Amount) error {
 	if amount < 0 {
 		return errors.New("TotalCredits must be greater than or equal to 0")
 	}
-	if amount > 9999999999.99 {
+	if amount > 999999999999 {
 		return errors.New("TotalCredits must be less than or equal to 9999999999.99")
 	}
 
-	f.TotalCredits = util.ToFixedWidthZeroString(strconv.Itoa(int(amount*100)), 12)
+	f.TotalCredits = amount.format(12)
+	return nil
+}
+
+// TotalCreditsCents returns the TotalCredits as an exact number of cents
+func (f *NachaFileControl) TotalCreditsCents() (Amount, error) {
+	return parseAmountField("TotalCredits", f.TotalCredits)
+}
+
+// Parse populates the NachaFileControl from a 94 character record
+func (f *NachaFileControl) Parse(record string) error {
+	if err := checkRecord(record, "9"); err != nil {
+		return err
+	}
+
+	f.Type = record[0:1]
+	f.BatchCount = record[1:7]
+	f.BlockCount = record[7:13]
+	f.EntryAddendaCount = record[13:21]
+	f.EntryHash = record[21:31]
+	f.TotalDebits = record[31:43]
+	f.TotalCredits = record[43:55]
+	f.Reserved = record[55:94]
 	return nil
 }
+
+// String returns the NachaFileControl as a 94 character record
+func (f *NachaFileControl) String() string {
+	return f.Type + f.BatchCount + f.BlockCount + f.EntryAddendaCount +
+		f.EntryHash + f.TotalDebits + f.TotalCredits + f.Reserved
+}
+
+// BatchCountValue returns the BatchCount as an int
+func (f *NachaFileControl) BatchCountValue() (int, error) {
+	return parseIntField("BatchCount", f.BatchCount)
+}
+
+// BlockCountValue returns the BlockCount as an int
+func (f *NachaFileControl) BlockCountValue() (int, error) {
+	return parseIntField("BlockCount", f.BlockCount)
+}
+
+// EntryAddendaCountValue returns the EntryAddendaCount as an int
+func (f *NachaFileControl) EntryAddendaCountValue() (int, error) {
+	return parseIntField("EntryAddendaCount", f.EntryAddendaCount)
+}
+
+// EntryHashValue returns the EntryHash as an int64
+func (f *NachaFileControl) EntryHashValue() (int64, error) {
+	return parseNumberField("EntryHash", f.EntryHash)
+}
@@ -0,0 +1,22 @@
+package types
+
+import "testing"
+
+func TestFileControlGetters(t *testing.T) {
+	control := &NachaFileControl{}
+	must(t, control.Parse("9000001000001000000030004200004000000023465000000000000                                       "))
+	malformed := &NachaFileControl{BatchCount: "00000-", BlockCount: "1", EntryAddendaCount: "0000000A", EntryHash: " ", TotalCredits: "00000000000O"}
+
+	runGetterTests(t, []getterTest{
+		{name: "BatchCountValue", get: func() (any, error) { return control.BatchCountValue() }, want: 1},
+		{name: "BlockCountValue", get: func() (any, error) { return control.BlockCountValue() }, want: 1},
+		{name: "EntryAddendaCountValue", get: func() (any, error) { return control.EntryAddendaCountValue() }, want: 3},
+		{name: "EntryHashValue", get: func() (any, error) { return control.EntryHashValue() }, want: int64(4200004)},
+		{name: "TotalDebitsCents", get: func() (any, error) { return control.TotalDebitsCents() }, want: Dollars(234, 65)},
+		{name: "TotalCreditsCents", get: func() (any, error) { return control.TotalCreditsCents() }, want: Amount(0)},
+		{name: "malformed BatchCount", get: func() (any, error) { return malformed.BatchCountValue() }, wantErr: "BatchCount must be numeric"},
+		{name: "malformed EntryAddendaCount", get: func() (any, error) { return malformed.EntryAddendaCountValue() }, wantErr: "EntryAddendaCount must be numeric"},
+		{name: "blank EntryHash", get: func() (any, error) { return malformed.EntryHashValue() }, wantErr: "EntryHash must be numeric"},
+		{name: "malformed TotalCredits", get: func() (any, error) { return malformed.TotalCreditsCents() }, wantErr: "TotalCredits must be numeric"},
+	})
+}
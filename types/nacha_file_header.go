@@ -175,3 +175,52 @@ func (h *NachaFileHeader) SetReferenceCode(code string) error {
 func (h *NachaFileHeader) SetReferenceCodeToDefault() {
 	h.ReferenceCode = util.ToFixedWidthString("", 8, false)
 }
+
+// Parse populates the NachaFileHeader from a 94 character record
+func (h *NachaFileHeader) Parse(record string) error {
+	if err := checkRecord(record, "1"); err != nil {
+		return err
+	}
+
+	h.Type = record[0:1]
+	h.PriorityCode = record[1:3]
+	h.ImmediateDestination = record[3:13]
+	h.ImmediateOrigin = record[13:23]
+	h.FileCreationDate = record[23:29]
+	h.FileCreationTime = record[29:33]
+	h.FileIDModifier = record[33:34]
+	h.RecordSize = record[34:37]
+	h.BlockingFactor = record[37:39]
+	h.FormatCode = record[39:40]
+	h.ImmediateDestinationName = record[40:63]
+	h.ImmediateOriginName = record[63:86]
+	h.ReferenceCode = record[86:94]
+	return nil
+}
+
+// String returns the NachaFileHeader as a 94 character record
+func (h *NachaFileHeader) String() string {
+	return h.Type + h.PriorityCode + h.ImmediateDestination + h.ImmediateOrigin +
+		h.FileCreationDate + h.FileCreationTime + h.FileIDModifier +
+		h.RecordSize + h.BlockingFactor + h.FormatCode +
+		h.ImmediateDestinationName + h.ImmediateOriginName + h.ReferenceCode
+}
+
+// CreationTime returns the FileCreationDate and FileCreationTime as a time.Time in UTC.
+// A blank FileCreationTime is treated as midnight.
+func (h *NachaFileHeader) CreationTime() (time.Time, error) {
+	date, err := parseDateField("FileCreationDate", h.FileCreationDate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if strings.TrimSpace(h.FileCreationTime) == "" {
+		return date, nil
+	}
+
+	clock, err := time.Parse("1504", h.FileCreationTime)
+	if err != nil {
+		return time.Time{}, errors.New("FileCreationTime must be a valid time in the HHMM format")
+	}
+
+	return date.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute), nil
+}
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFileHeaderGetters(t *testing.T) {
+	header := &NachaFileHeader{FileCreationDate: "261016", FileCreationTime: "1749"}
+
+	runGetterTests(t, []getterTest{
+		{name: "CreationTime", get: func() (any, error) { return header.CreationTime() }, want: time.Date(2026, 10, 16, 17, 49, 0, 0, time.UTC)},
+		{name: "blank FileCreationTime", get: func() (any, error) {
+			return (&NachaFileHeader{FileCreationDate: "261016", FileCreationTime: "    "}).CreationTime()
+		}, want: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "malformed FileCreationDate", get: func() (any, error) { return (&NachaFileHeader{FileCreationDate: "26101"}).CreationTime() }, wantErr: "FileCreationDate must be a valid date in the YYMMDD format"},
+		{name: "malformed FileCreationTime", get: func() (any, error) {
+			return (&NachaFileHeader{FileCreationDate: "261016", FileCreationTime: "2561"}).CreationTime()
+		}, wantErr: "FileCreationTime must be a valid time in the HHMM format"},
+	})
+}
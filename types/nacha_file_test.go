@@ -0,0 +1,49 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenerateFileTwice(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     int  // Debit entries in the batch
+		offset      bool // Balance the batch with an offset entry
+		wantFillers int
+	}{
+		{name: "full block", entries: 6, wantFillers: 0},
+		{name: "one record over a block", entries: 7, wantFillers: 9},
+		{name: "partial block", entries: 2, wantFillers: 4},
+		{name: "offset", entries: 5, offset: true, wantFillers: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			addTestBatch(t, file, "PPD", 200, slices.Repeat([]int{27}, tt.entries)...)
+			if tt.offset {
+				file.Offset = &NachaOffset{
+					RoutingNumber:    "011000015",
+					DFIAccountNumber: "123456789",
+					AccountType:      AccountTypeChecking,
+					Name:             "ABC Company",
+				}
+			}
+
+			must(t, file.GenerateFile())
+			first := file.String()
+			must(t, file.GenerateFile())
+
+			if got := file.String(); got != first {
+				t.Errorf("second GenerateFile() =\n%s\nwant\n%s", got, first)
+			}
+			if len(file.BlockFillers) != tt.wantFillers {
+				t.Errorf("GenerateFile() added %d block fillers, want %d", len(file.BlockFillers), tt.wantFillers)
+			}
+			if err := file.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
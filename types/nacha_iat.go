@@ -0,0 +1,539 @@
+package types
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rashintha/nacha/util"
+)
+
+// IAT batch headers reuse the NachaBatchHeader fields with the following layout:
+//   CompanyName              - IAT Indicator (Char Count: 16 | Value: Blank)
+//   CompanyDiscretionaryData - Foreign Exchange Indicator (Char Count: 2), Foreign Exchange Reference Indicator (Char Count: 1),
+//                              Foreign Exchange Reference (Char Count: 15) and ISO Destination Country Code (Char Count: 2)
+//   CompanyIdentification    - Originator Identification (Char Count: 10)
+//   CompanyDescriptiveDate   - ISO Originating Currency Code (Char Count: 3) and ISO Destination Currency Code (Char Count: 3)
+//
+// IAT entries reuse the NachaEntry fields with the following layout:
+//   DFIAccountNumber                    - Number of Addenda Records (Char Count: 4) and Reserved (Char Count: 13 | Value: Blank)
+//   IndividualIDNumber & IndividualName - Foreign Receiver's Account Number (Char Count: 35) and Reserved (Char Count: 2 | Value: Blank)
+//   DiscretionaryData                   - Gateway Operator OFAC Screening Indicator (Char Count: 1) and
+//                                         Secondary OFAC Screening Indicator (Char Count: 1)
+
+// maxIATAddenda is the maximum number of addenda records an IAT entry can carry
+const maxIATAddenda = 14
+
+// iatTransactionTypeCodes are the Transaction Type Codes allowed in the IAT Addenda 10
+var iatTransactionTypeCodes = []string{
+	"ANN", "BUS", "DEP", "LOA", "MIS", "MOR", "PEN", "RLS", "REM", "SAL", "TAX",
+	"ARC", "BOC", "MTE", "POP", "POS", "SHR", "TEL", "WEB",
+}
+
+// iatDFIIDNumberQualifiers are the DFI Identification Number Qualifiers allowed in the IAT addenda
+var iatDFIIDNumberQualifiers = []string{
+	"01", // National Clearing System
+	"02", // BIC Code
+	"03", // IBAN Code
+}
+
+// SetIATIndicator sets the IAT Indicator held in the CompanyName of IAT batches to blanks
+func (h *NachaBatchHeader) SetIATIndicator() {
+	h.CompanyName = util.ToFixedWidthString("", 16, false)
+}
+
+// iatDiscretionaryData makes sure the CompanyDiscretionaryData is 20 characters before one of its IAT parts is changed
+func (h *NachaBatchHeader) iatDiscretionaryData() {
+	if len(h.CompanyDiscretionaryData) != 20 {
+		h.CompanyDiscretionaryData = util.ToFixedWidthString("", 20, false)
+	}
+}
+
+// iatDiscretionaryField returns one of the IAT parts of the CompanyDiscretionaryData, or blank if it is not 20 characters
+func (h *NachaBatchHeader) iatDiscretionaryField(start int, end int) string {
+	if len(h.CompanyDiscretionaryData) != 20 {
+		return ""
+	}
+
+	return h.CompanyDiscretionaryData[start:end]
+}
+
+// SetForeignExchange sets the Foreign Exchange Indicator (FF, FV or VF), the Foreign Exchange Reference Indicator
+// (1 - Foreign Exchange Rate, 2 - Foreign Exchange Reference Number, 3 - Space Filled) and the Foreign Exchange Reference of IAT batches
+func (h *NachaBatchHeader) SetForeignExchange(indicator string, referenceIndicator int, reference string) error {
+	if indicator != "FF" && indicator != "FV" && indicator != "VF" {
+		return errors.New("ForeignExchangeIndicator must be FF, FV or VF")
+	}
+	if referenceIndicator < 1 || referenceIndicator > 3 {
+		return errors.New("ForeignExchangeReferenceIndicator must be 1, 2 or 3")
+	}
+	if len(reference) > 15 {
+		return errors.New("ForeignExchangeReference must be 15 characters or less")
+	}
+	if referenceIndicator == 3 && reference != "" {
+		return errors.New("ForeignExchangeReference must be blank when ForeignExchangeReferenceIndicator is 3")
+	}
+
+	h.iatDiscretionaryData()
+	h.CompanyDiscretionaryData = indicator + strconv.Itoa(referenceIndicator) +
+		util.ToFixedWidthString(strings.ToUpper(reference), 15, false) + h.CompanyDiscretionaryData[18:]
+	return nil
+}
+
+// ForeignExchangeIndicator returns the Foreign Exchange Indicator of an IAT batch
+func (h *NachaBatchHeader) ForeignExchangeIndicator() string {
+	return h.iatDiscretionaryField(0, 2)
+}
+
+// ForeignExchangeReferenceIndicator returns the Foreign Exchange Reference Indicator of an IAT batch
+func (h *NachaBatchHeader) ForeignExchangeReferenceIndicator() (int, error) {
+	return parseIntField("ForeignExchangeReferenceIndicator", h.iatDiscretionaryField(2, 3))
+}
+
+// ForeignExchangeReference returns the Foreign Exchange Reference of an IAT batch
+func (h *NachaBatchHeader) ForeignExchangeReference() string {
+	return strings.TrimSpace(h.iatDiscretionaryField(3, 18))
+}
+
+// SetISODestinationCountryCode sets the 2 character ISO Destination Country Code of IAT batches
+func (h *NachaBatchHeader) SetISODestinationCountryCode(code string) error {
+	if len(code) != 2 || !isLetters(code) {
+		return errors.New("ISODestinationCountryCode must be 2 letters")
+	}
+
+	h.iatDiscretionaryData()
+	h.CompanyDiscretionaryData = h.CompanyDiscretionaryData[:18] + strings.ToUpper(code)
+	return nil
+}
+
+// ISODestinationCountryCode returns the ISO Destination Country Code of an IAT batch
+func (h *NachaBatchHeader) ISODestinationCountryCode() string {
+	return h.iatDiscretionaryField(18, 20)
+}
+
+// SetOriginatorIdentification sets the Originator Identification held in the CompanyIdentification of IAT batches
+func (h *NachaBatchHeader) SetOriginatorIdentification(id string) error {
+	return h.SetCompanyIdentification(id)
+}
+
+// SetISOCurrencyCodes sets the 3 character ISO Originating Currency Code and ISO Destination Currency Code of IAT batches
+func (h *NachaBatchHeader) SetISOCurrencyCodes(originating string, destination string) error {
+	if len(originating) != 3 || !isLetters(originating) {
+		return errors.New("ISOOriginatingCurrencyCode must be 3 letters")
+	}
+	if len(destination) != 3 || !isLetters(destination) {
+		return errors.New("ISODestinationCurrencyCode must be 3 letters")
+	}
+
+	h.CompanyDescriptiveDate = strings.ToUpper(originating + destination)
+	return nil
+}
+
+// ISOOriginatingCurrencyCode returns the ISO Originating Currency Code of an IAT batch
+func (h *NachaBatchHeader) ISOOriginatingCurrencyCode() string {
+	if len(h.CompanyDescriptiveDate) != 6 {
+		return ""
+	}
+
+	return h.CompanyDescriptiveDate[:3]
+}
+
+// ISODestinationCurrencyCode returns the ISO Destination Currency Code of an IAT batch
+func (h *NachaBatchHeader) ISODestinationCurrencyCode() string {
+	if len(h.CompanyDescriptiveDate) != 6 {
+		return ""
+	}
+
+	return h.CompanyDescriptiveDate[3:]
+}
+
+// SetIATNumberOfAddendaRecords sets the Number of Addenda Records held in the DFIAccountNumber of IAT entries
+func (e *NachaEntry) SetIATNumberOfAddendaRecords(count int) error {
+	if count < 7 || count > maxIATAddenda {
+		return errors.New("IATNumberOfAddendaRecords must be between 7 and 14")
+	}
+
+	e.DFIAccountNumber = util.ToFixedWidthZeroString(strconv.Itoa(count), 4) + util.ToFixedWidthString("", 13, false)
+	return nil
+}
+
+// IATNumberOfAddendaRecords returns the Number of Addenda Records of an IAT entry
+func (e *NachaEntry) IATNumberOfAddendaRecords() (int, error) {
+	if len(e.DFIAccountNumber) != 17 {
+		return 0, errors.New("DFIAccountNumber must be 17 characters")
+	}
+
+	return parseIntField("IATNumberOfAddendaRecords", e.DFIAccountNumber[:4])
+}
+
+// SetForeignReceiverAccountNumber sets the Foreign Receiver's Account Number held in the
+// IndividualIDNumber and IndividualName of IAT entries
+func (e *NachaEntry) SetForeignReceiverAccountNumber(number string) error {
+	if number == "" {
+		return errors.New("ForeignReceiverAccountNumber cannot be empty")
+	}
+	if len(number) > 35 {
+		return errors.New("ForeignReceiverAccountNumber must be 35 characters or less")
+	}
+
+	account := util.ToFixedWidthString(number, 37, false)
+	e.IndividualIDNumber = account[:15]
+	e.IndividualName = account[15:]
+	return nil
+}
+
+// ForeignReceiverAccountNumber returns the Foreign Receiver's Account Number of an IAT entry
+func (e *NachaEntry) ForeignReceiverAccountNumber() string {
+	return strings.TrimSpace(e.IndividualIDNumber + e.IndividualName)
+}
+
+// SetOFACScreeningIndicators sets the Gateway Operator and Secondary OFAC Screening Indicators
+// held in the DiscretionaryData of IAT entries
+func (e *NachaEntry) SetOFACScreeningIndicators(gatewayOperator bool, secondary bool) {
+	e.DiscretionaryData = ofacIndicator(gatewayOperator) + ofacIndicator(secondary)
+}
+
+// ofacIndicator returns 1 when the entry is suspected of involving an OFAC sanctioned party and 0 otherwise
+func ofacIndicator(suspect bool) string {
+	if suspect {
+		return "1"
+	}
+
+	return "0"
+}
+
+// IATAddenda is implemented by the typed builders of the IAT addenda records (types 10 to 18)
+type IATAddenda interface {
+	// addendaTypeCode returns the AddendaTypeCode of the addenda record
+	addendaTypeCode() string
+	// paymentRelatedInformation returns the 80 characters held in positions 4 to 83 of the addenda record
+	paymentRelatedInformation() (string, error)
+}
+
+// AddIATAddenda builds an IAT addenda record, appends it to the entry and updates the Number of Addenda Records.
+// Addenda 17 and 18 records are numbered with their AddendaSequenceNumber in the order they are added.
+// An entry can carry at most 14 addenda records: the 7 mandatory ones, 2 addenda 17 and 5 addenda 18.
+func (e *NachaEntry) AddIATAddenda(a IATAddenda) (*NachaAddenda, error) {
+	if len(e.Addenda) >= maxIATAddenda {
+		return nil, errors.New("IAT entries can have at most 14 addenda records")
+	}
+
+	info, err := a.paymentRelatedInformation()
+	if err != nil {
+		return nil, err
+	}
+
+	addenda := &NachaAddenda{}
+	addenda.Default()
+	addenda.AddendaTypeCode = a.addendaTypeCode()
+	addenda.PaymentRelatedInformation = info
+	addenda.AddendaSequenceNumber = util.ToFixedWidthString("", 4, false)
+
+	if addenda.hasAddendaSequenceNumber() {
+		sequence := 1
+		for _, existing := range e.Addenda {
+			if existing.AddendaTypeCode == addenda.AddendaTypeCode {
+				sequence++
+			}
+		}
+		_ = addenda.SetAddendaSequenceNumber(sequence)
+	}
+	if len(e.TraceNumber) == 15 {
+		addenda.EntryDetailSequenceNumber = e.TraceNumber[8:]
+	}
+
+	e.Addenda = append(e.Addenda, addenda)
+	e.AddendaRecordIndicator = "1"
+	e.DFIAccountNumber = util.ToFixedWidthZeroString(strconv.Itoa(len(e.Addenda)), 4) + util.ToFixedWidthString("", 13, false)
+	return addenda, nil
+}
+
+// IATAddenda10 identifies the transaction and the receiver of an IAT entry
+type IATAddenda10 struct {
+	TransactionTypeCode  string // Char Count: 3 | Values: ANN, BUS, DEP, LOA, MIS, MOR, PEN, RLS, REM, SAL, TAX, ARC, BOC, MTE, POP, POS, SHR, TEL or WEB
+	ForeignPaymentAmount Amount // Char Count: 18 | Value: Amount for inbound IAT entries, zero for outbound IAT entries
+	ForeignTraceNumber   string // Char Count: 22 | Optional
+	ReceiverName         string // Char Count: 35 | Value: Receiving Company Name or Individual Name
+}
+
+func (a IATAddenda10) addendaTypeCode() string { return "10" }
+
+func (a IATAddenda10) paymentRelatedInformation() (string, error) {
+	if !slices.Contains(iatTransactionTypeCodes, a.TransactionTypeCode) {
+		return "", errors.New("TransactionTypeCode must be one of " + strings.Join(iatTransactionTypeCodes, ", "))
+	}
+	if a.ForeignPaymentAmount < 0 || a.ForeignPaymentAmount > 999999999999999999 {
+		return "", errors.New("ForeignPaymentAmount must be between 0 and 9999999999999999.99")
+	}
+	if len(a.ForeignTraceNumber) > 22 {
+		return "", errors.New("ForeignTraceNumber must be 22 characters or less")
+	}
+	if a.ReceiverName == "" {
+		return "", errors.New("ReceiverName cannot be empty")
+	}
+
+	return a.TransactionTypeCode + a.ForeignPaymentAmount.format(18) +
+		util.ToFixedWidthString(a.ForeignTraceNumber, 22, false) +
+		iatText(a.ReceiverName, 35) + util.ToFixedWidthString("", 2, false), nil
+}
+
+// IATAddenda11 identifies the originator of an IAT entry
+type IATAddenda11 struct {
+	OriginatorName          string // Char Count: 35
+	OriginatorStreetAddress string // Char Count: 35
+}
+
+func (a IATAddenda11) addendaTypeCode() string { return "11" }
+
+func (a IATAddenda11) paymentRelatedInformation() (string, error) {
+	if a.OriginatorName == "" {
+		return "", errors.New("OriginatorName cannot be empty")
+	}
+	if a.OriginatorStreetAddress == "" {
+		return "", errors.New("OriginatorStreetAddress cannot be empty")
+	}
+
+	return iatText(a.OriginatorName, 35) + iatText(a.OriginatorStreetAddress, 35) + util.ToFixedWidthString("", 10, false), nil
+}
+
+// IATAddenda12 holds the city, state or province, country and postal code of the originator of an IAT entry
+type IATAddenda12 struct {
+	OriginatorCity          string
+	OriginatorStateProvince string
+	OriginatorCountry       string // ISO country code
+	OriginatorPostalCode    string
+}
+
+func (a IATAddenda12) addendaTypeCode() string { return "12" }
+
+func (a IATAddenda12) paymentRelatedInformation() (string, error) {
+	if a.OriginatorCity == "" {
+		return "", errors.New("OriginatorCity cannot be empty")
+	}
+	if a.OriginatorCountry == "" {
+		return "", errors.New("OriginatorCountry cannot be empty")
+	}
+
+	return iatText(iatPair(a.OriginatorCity, a.OriginatorStateProvince), 35) +
+		iatText(iatPair(a.OriginatorCountry, a.OriginatorPostalCode), 35) + util.ToFixedWidthString("", 10, false), nil
+}
+
+// IATAddenda13 identifies the Originating DFI of an IAT entry
+type IATAddenda13 struct {
+	ODFIName              string // Char Count: 35
+	ODFIIDNumberQualifier string // Char Count: 2 | Values: 01 - National Clearing System, 02 - BIC Code, 03 - IBAN Code
+	ODFIIdentification    string // Char Count: 34
+	ODFIBranchCountryCode string // Char Count: 3 | Value: ISO country code
+}
+
+func (a IATAddenda13) addendaTypeCode() string { return "13" }
+
+func (a IATAddenda13) paymentRelatedInformation() (string, error) {
+	return iatDFI("ODFI", a.ODFIName, a.ODFIIDNumberQualifier, a.ODFIIdentification, a.ODFIBranchCountryCode)
+}
+
+// IATAddenda14 identifies the Receiving DFI of an IAT entry
+type IATAddenda14 struct {
+	RDFIName              string // Char Count: 35
+	RDFIIDNumberQualifier string // Char Count: 2 | Values: 01 - National Clearing System, 02 - BIC Code, 03 - IBAN Code
+	RDFIIdentification    string // Char Count: 34
+	RDFIBranchCountryCode string // Char Count: 3 | Value: ISO country code
+}
+
+func (a IATAddenda14) addendaTypeCode() string { return "14" }
+
+func (a IATAddenda14) paymentRelatedInformation() (string, error) {
+	return iatDFI("RDFI", a.RDFIName, a.RDFIIDNumberQualifier, a.RDFIIdentification, a.RDFIBranchCountryCode)
+}
+
+// IATAddenda15 identifies the receiver of an IAT entry
+type IATAddenda15 struct {
+	ReceiverIdentificationNumber string // Char Count: 15 | Optional
+	ReceiverStreetAddress        string // Char Count: 35
+}
+
+func (a IATAddenda15) addendaTypeCode() string { return "15" }
+
+func (a IATAddenda15) paymentRelatedInformation() (string, error) {
+	if len(a.ReceiverIdentificationNumber) > 15 {
+		return "", errors.New("ReceiverIdentificationNumber must be 15 characters or less")
+	}
+	if a.ReceiverStreetAddress == "" {
+		return "", errors.New("ReceiverStreetAddress cannot be empty")
+	}
+
+	return iatText(a.ReceiverIdentificationNumber, 15) + iatText(a.ReceiverStreetAddress, 35) + util.ToFixedWidthString("", 30, false), nil
+}
+
+// IATAddenda16 holds the city, state or province, country and postal code of the receiver of an IAT entry
+type IATAddenda16 struct {
+	ReceiverCity          string
+	ReceiverStateProvince string
+	ReceiverCountry       string // ISO country code
+	ReceiverPostalCode    string
+}
+
+func (a IATAddenda16) addendaTypeCode() string { return "16" }
+
+func (a IATAddenda16) paymentRelatedInformation() (string, error) {
+	if a.ReceiverCity == "" {
+		return "", errors.New("ReceiverCity cannot be empty")
+	}
+	if a.ReceiverCountry == "" {
+		return "", errors.New("ReceiverCountry cannot be empty")
+	}
+
+	return iatText(iatPair(a.ReceiverCity, a.ReceiverStateProvince), 35) +
+		iatText(iatPair(a.ReceiverCountry, a.ReceiverPostalCode), 35) + util.ToFixedWidthString("", 10, false), nil
+}
+
+// IATAddenda17 carries optional remittance information of an IAT entry (at most 2 per entry)
+type IATAddenda17 struct {
+	PaymentRelatedInformation string // Char Count: 80
+}
+
+func (a IATAddenda17) addendaTypeCode() string { return "17" }
+
+func (a IATAddenda17) paymentRelatedInformation() (string, error) {
+	if a.PaymentRelatedInformation == "" {
+		return "", errors.New("PaymentRelatedInformation cannot be empty")
+	}
+
+	return iatText(a.PaymentRelatedInformation, 80), nil
+}
+
+// IATAddenda18 identifies an optional foreign correspondent bank of an IAT entry (at most 5 per entry)
+type IATAddenda18 struct {
+	ForeignCorrespondentBankName              string // Char Count: 35
+	ForeignCorrespondentBankIDNumberQualifier string // Char Count: 2 | Values: 01 - National Clearing System, 02 - BIC Code, 03 - IBAN Code
+	ForeignCorrespondentBankIDNumber          string // Char Count: 34
+	ForeignCorrespondentBankBranchCountryCode string // Char Count: 3 | Value: ISO country code
+}
+
+func (a IATAddenda18) addendaTypeCode() string { return "18" }
+
+func (a IATAddenda18) paymentRelatedInformation() (string, error) {
+	return iatDFI("ForeignCorrespondentBank", a.ForeignCorrespondentBankName, a.ForeignCorrespondentBankIDNumberQualifier,
+		a.ForeignCorrespondentBankIDNumber, a.ForeignCorrespondentBankBranchCountryCode)
+}
+
+// iatDFI formats the name, identification number qualifier, identification and branch country code of a
+// financial institution as used by the IAT addenda 13, 14 and 18
+func iatDFI(prefix string, name string, qualifier string, id string, countryCode string) (string, error) {
+	if name == "" {
+		return "", errors.New(prefix + "Name cannot be empty")
+	}
+	if !slices.Contains(iatDFIIDNumberQualifiers, qualifier) {
+		return "", errors.New(prefix + "IDNumberQualifier must be one of " + strings.Join(iatDFIIDNumberQualifiers, ", "))
+	}
+	if id == "" {
+		return "", errors.New(prefix + "Identification cannot be empty")
+	}
+	if len(id) > 34 {
+		return "", errors.New(prefix + "Identification must be 34 characters or less")
+	}
+	if len(countryCode) != 2 && len(countryCode) != 3 {
+		return "", errors.New(prefix + "BranchCountryCode must be 2 or 3 characters")
+	}
+
+	return iatText(name, 35) + qualifier + util.ToFixedWidthString(id, 34, false) +
+		iatText(countryCode, 3) + util.ToFixedWidthString("", 6, false), nil
+}
+
+// iatPair joins two address parts with the * separator and the \ terminator required by the IAT addenda
+func iatPair(first string, second string) string {
+	return first + "*" + second + `\`
+}
+
+// iatText returns an upper case, left aligned IAT field of the given width
+func iatText(s string, width int) string {
+	return util.ToFixedWidthString(strings.ToUpper(s), width, false)
+}
+
+// isLetters reports whether the value is not empty and only contains letters
+func isLetters(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, c := range strings.ToUpper(value) {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateIATBatchHeader checks the IAT specific fields of a batch header
+func validateIATBatchHeader(v *validator, header *NachaBatchHeader) {
+	if len(header.CompanyDiscretionaryData) != 20 || len(header.CompanyDescriptiveDate) != 6 {
+		return
+	}
+
+	if !slices.Contains([]string{"FF", "FV", "VF"}, header.ForeignExchangeIndicator()) {
+		v.add("CompanyDiscretionaryData", "IAT Foreign Exchange Indicator must be FF, FV or VF")
+	}
+	if indicator, err := header.ForeignExchangeReferenceIndicator(); err != nil || indicator < 1 || indicator > 3 {
+		v.add("CompanyDiscretionaryData", "IAT Foreign Exchange Reference Indicator must be 1, 2 or 3")
+	}
+	if !isLetters(header.ISODestinationCountryCode()) {
+		v.add("CompanyDiscretionaryData", "IAT ISO Destination Country Code must be 2 letters")
+	}
+	if !isLetters(header.ISOOriginatingCurrencyCode()) {
+		v.add("CompanyDescriptiveDate", "IAT ISO Originating Currency Code must be 3 letters")
+	}
+	if !isLetters(header.ISODestinationCurrencyCode()) {
+		v.add("CompanyDescriptiveDate", "IAT ISO Destination Currency Code must be 3 letters")
+	}
+}
+
+// validateIATEntry checks the IAT layout of an entry and the order of its mandatory and optional addenda records
+func validateIATEntry(v *validator, entry *NachaEntry) {
+	if count, err := entry.IATNumberOfAddendaRecords(); err != nil {
+		v.add("DFIAccountNumber", "IAT entries must start with the 4 digit Number of Addenda Records")
+	} else if count != len(entry.Addenda) {
+		v.add("DFIAccountNumber", "IAT Number of Addenda Records must be "+strconv.Itoa(len(entry.Addenda))+" to match the addenda records")
+	}
+
+	if entry.ForeignReceiverAccountNumber() == "" {
+		v.add("IndividualIDNumber", "IAT entries must carry the Foreign Receiver's Account Number")
+	}
+	for _, c := range entry.DiscretionaryData {
+		if c != '0' && c != '1' && c != ' ' {
+			v.add("DiscretionaryData", "IAT OFAC Screening Indicators must be 0, 1 or blank")
+			break
+		}
+	}
+
+	mandatory := []string{"10", "11", "12", "13", "14", "15", "16"}
+	count17 := 0
+	count18 := 0
+	for i, addenda := range entry.Addenda {
+		switch {
+		case i < len(mandatory):
+			if addenda.AddendaTypeCode != mandatory[i] {
+				v.add("Addenda", "IAT entries must start with the mandatory addenda records 10 to 16 in order")
+				return
+			}
+		case addenda.AddendaTypeCode == "17" && count18 == 0:
+			count17++
+		case addenda.AddendaTypeCode == "18":
+			count18++
+		default:
+			v.add("Addenda", "IAT optional addenda records must be 17 followed by 18")
+			return
+		}
+	}
+
+	if len(entry.Addenda) < len(mandatory) {
+		v.add("Addenda", "IAT entries must carry the mandatory addenda records 10 to 16")
+	}
+	if count17 > 2 {
+		v.add("Addenda", "IAT entries can have at most 2 addenda 17 records")
+	}
+	if count18 > 5 {
+		v.add("Addenda", "IAT entries can have at most 5 addenda 18 records")
+	}
+}
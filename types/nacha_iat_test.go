@@ -0,0 +1,211 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// iatMandatoryAddenda are the mandatory addenda 10 to 16 of the test IAT entries
+var iatMandatoryAddenda = []IATAddenda{
+	IATAddenda10{TransactionTypeCode: "BUS", ReceiverName: "BBC Company"},
+	IATAddenda11{OriginatorName: "ABC Company", OriginatorStreetAddress: "1 Main Street"},
+	IATAddenda12{OriginatorCity: "New York", OriginatorStateProvince: "NY", OriginatorCountry: "US", OriginatorPostalCode: "10001"},
+	IATAddenda13{ODFIName: "Origin Bank", ODFIIDNumberQualifier: "01", ODFIIdentification: "011000015", ODFIBranchCountryCode: "US"},
+	IATAddenda14{RDFIName: "Receiving Bank", RDFIIDNumberQualifier: "02", RDFIIdentification: "BBCBCATT", RDFIBranchCountryCode: "CA"},
+	IATAddenda15{ReceiverIdentificationNumber: "392344", ReceiverStreetAddress: "2 Bay Street"},
+	IATAddenda16{ReceiverCity: "Toronto", ReceiverStateProvince: "ON", ReceiverCountry: "CA", ReceiverPostalCode: "M5J2N8"},
+}
+
+// addIATTestBatch adds an outbound IAT batch with one credit entry carrying the mandatory addenda records
+func addIATTestBatch(t *testing.T, file *NachaFile) *NachaBatch {
+	t.Helper()
+
+	batch := file.NewBatch()
+	must(t, batch.Header.SetServiceClassCode(220))
+	batch.Header.SetIATIndicator()
+	must(t, batch.Header.SetForeignExchange("FV", 3, ""))
+	must(t, batch.Header.SetISODestinationCountryCode("CA"))
+	must(t, batch.Header.SetOriginatorIdentification("1122334455"))
+	must(t, batch.Header.SetStandardEntryClassCode("IAT"))
+	must(t, batch.Header.SetCompanyEntryDescription("Payroll"))
+	must(t, batch.Header.SetISOCurrencyCodes("USD", "CAD"))
+	batch.Header.SetEffectiveEntryDate(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	must(t, batch.Header.SetODFIIdentification(testODFI))
+	must(t, batch.Header.SetBatchNumber(len(file.Batches)))
+
+	entry := batch.AddEntry()
+	must(t, entry.SetTransactionCode(22))
+	must(t, entry.SetReceivingDFI("021000021"))
+	must(t, entry.SetAmount(Dollars(100, 25)))
+	must(t, entry.SetForeignReceiverAccountNumber("CA29079117000000042"))
+	entry.SetOFACScreeningIndicators(false, false)
+	must(t, entry.SetTraceNumber(testODFI, 1))
+	for _, a := range iatMandatoryAddenda {
+		if _, err := entry.AddIATAddenda(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return batch
+}
+
+func TestAddIATAddenda(t *testing.T) {
+	batch := addIATTestBatch(t, newTestFile(t))
+	entry := batch.Entries[0]
+
+	for range 2 {
+		if _, err := entry.AddIATAddenda(IATAddenda17{PaymentRelatedInformation: "Invoice 42"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 5 {
+		if _, err := entry.AddIATAddenda(IATAddenda18{
+			ForeignCorrespondentBankName:              "Correspondent Bank",
+			ForeignCorrespondentBankIDNumberQualifier: "02",
+			ForeignCorrespondentBankIDNumber:          "CORRGB2L",
+			ForeignCorrespondentBankBranchCountryCode: "GB",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if count, err := entry.IATNumberOfAddendaRecords(); err != nil || count != 14 || !entry.HasAddenda() {
+		t.Errorf("IATNumberOfAddendaRecords() = %d, %v, indicator %q", count, err, entry.AddendaRecordIndicator)
+	}
+	for i, a := range entry.Addenda {
+		want := "    "
+		switch {
+		case i >= 9:
+			want = fmt.Sprintf("%04d", i-8)
+		case i >= 7:
+			want = fmt.Sprintf("%04d", i-6)
+		}
+		if a.AddendaSequenceNumber != want || a.EntryDetailSequenceNumber != "0000001" || len(a.String()) != 94 {
+			t.Errorf("Addenda[%d] = %q", i, a.String())
+		}
+	}
+	if got := entry.Addenda[0].PaymentRelatedInformation[:21]; got != "BUS000000000000000000" {
+		t.Errorf("Addenda 10 starts with %q, want the Transaction Type Code and a zero Foreign Payment Amount", got)
+	}
+	if got := entry.Addenda[2].PaymentRelatedInformation[:12]; got != `NEW YORK*NY\` {
+		t.Errorf("Addenda 12 starts with %q, want the city and state", got)
+	}
+
+	if _, err := entry.AddIATAddenda(IATAddenda17{PaymentRelatedInformation: "Invoice 43"}); err == nil || err.Error() != "IAT entries can have at most 14 addenda records" {
+		t.Errorf("AddIATAddenda() of a 15th addenda record error = %v", err)
+	}
+	if len(entry.Addenda) != 14 {
+		t.Errorf("AddIATAddenda() added a 15th addenda record")
+	}
+
+	invalid := []struct {
+		addenda IATAddenda
+		wantErr string
+	}{
+		{addenda: IATAddenda10{TransactionTypeCode: "XYZ", ReceiverName: "BBC Company"}, wantErr: "TransactionTypeCode must be one of"},
+		{addenda: IATAddenda11{OriginatorName: "ABC Company"}, wantErr: "OriginatorStreetAddress cannot be empty"},
+		{addenda: IATAddenda13{ODFIName: "Origin Bank", ODFIIDNumberQualifier: "04", ODFIIdentification: "011000015", ODFIBranchCountryCode: "US"}, wantErr: "ODFIIDNumberQualifier must be one of"},
+		{addenda: IATAddenda17{}, wantErr: "PaymentRelatedInformation cannot be empty"},
+	}
+	for _, tt := range invalid {
+		entry := &NachaEntry{}
+		entry.Default()
+		if _, err := entry.AddIATAddenda(tt.addenda); err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+			t.Errorf("AddIATAddenda(%T) error = %v, want %q", tt.addenda, err, tt.wantErr)
+		}
+		if len(entry.Addenda) != 0 {
+			t.Errorf("AddIATAddenda(%T) added an invalid addenda record", tt.addenda)
+		}
+	}
+}
+
+func TestGenerateAddendaIAT(t *testing.T) {
+	file := newTestFile(t)
+	batch := addIATTestBatch(t, file)
+	batch.Entries[0].Addenda = batch.Entries[0].Addenda[:6]
+
+	if err := file.GenerateFile(); err == nil || err.Error() != "IATNumberOfAddendaRecords must be between 7 and 14" {
+		t.Errorf("GenerateFile() error = %v, want the IAT addenda count error", err)
+	}
+}
+
+func TestValidateIAT(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(b *NachaBatch)
+		record int    // Record of the expected ValidationError, 0 for a valid file
+		field  string // Field of the expected ValidationError
+		rule   string // Part of the rule of the expected ValidationError
+	}{
+		{name: "valid batch", modify: func(b *NachaBatch) {}},
+		{
+			name:   "foreign exchange indicator",
+			modify: func(b *NachaBatch) { b.Header.CompanyDiscretionaryData = "XX3" + b.Header.CompanyDiscretionaryData[3:] },
+			record: 2, field: "CompanyDiscretionaryData", rule: "Foreign Exchange Indicator must be FF, FV or VF",
+		},
+		{
+			name:   "currency code",
+			modify: func(b *NachaBatch) { b.Header.CompanyDescriptiveDate = "US1CAD" },
+			record: 2, field: "CompanyDescriptiveDate", rule: "ISO Originating Currency Code must be 3 letters",
+		},
+		{
+			name:   "OFAC screening indicator",
+			modify: func(b *NachaBatch) { b.Entries[0].DiscretionaryData = "1X" },
+			record: 3, field: "DiscretionaryData", rule: "OFAC Screening Indicators must be 0, 1 or blank",
+		},
+		{
+			name: "addenda order",
+			modify: func(b *NachaBatch) {
+				addenda := b.Entries[0].Addenda
+				addenda[1], addenda[2] = addenda[2], addenda[1]
+			},
+			record: 3, field: "Addenda", rule: "mandatory addenda records 10 to 16 in order",
+		},
+		{
+			name:   "same day",
+			modify: func(b *NachaBatch) { b.Header.CompanyDescriptiveDate = "SD1300" },
+			record: 2, field: "StandardEntryClassCode", rule: "IAT entries are not eligible for Same Day ACH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			batch := addIATTestBatch(t, file)
+			must(t, file.GenerateFile())
+			tt.modify(batch)
+
+			err := file.Validate()
+			if tt.record == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			for _, e := range errs {
+				if e.Record == tt.record && e.Field == tt.field && strings.Contains(e.Rule, tt.rule) {
+					return
+				}
+			}
+			t.Errorf("Validate() error =\n%v\nwant record %d %s: %s", err, tt.record, tt.field, tt.rule)
+		})
+	}
+}
+
+func TestQualifiesForSameDayIAT(t *testing.T) {
+	batch := addIATTestBatch(t, newTestFile(t))
+	batch.Header.SetSameDay(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), SameDayWindow1)
+
+	err := batch.QualifiesForSameDay(time.Date(2026, 10, 16, 13, 0, 0, 0, time.UTC), SameDayWindow1)
+	if err == nil || err.Error() != "IAT entries are not eligible for Same Day ACH" {
+		t.Errorf("QualifiesForSameDay() error = %v, want the IAT error", err)
+	}
+}
@@ -0,0 +1,302 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/rashintha/nacha/routing"
+	"github.com/rashintha/nacha/util"
+)
+
+// Notification of change addenda records (type 98) reuse the NachaAddenda fields with the following layout:
+//   PaymentRelatedInformation - Change Code (Char Count: 3), Original Entry Trace Number (Char Count: 15), Reserved (Char Count: 6),
+//                               Original Receiving DFI Identification (Char Count: 8), Corrected Data (Char Count: 29),
+//                               Reserved (Char Count: 15) and the first 4 digits of the Trace Number
+//   AddendaSequenceNumber     - Digits 5 to 8 of the Trace Number
+//   EntryDetailSequenceNumber - Last 7 digits of the Trace Number
+
+// changeCodes maps the NACHA notification of change codes to their descriptions
+var changeCodes = map[string]string{
+	"C01": "Incorrect DFI Account Number",
+	"C02": "Incorrect Routing Number",
+	"C03": "Incorrect Routing Number and Incorrect DFI Account Number",
+	"C04": "Incorrect Individual Name/Receiving Company Name",
+	"C05": "Incorrect Transaction Code",
+	"C06": "Incorrect DFI Account Number and Incorrect Transaction Code",
+	"C07": "Incorrect Routing Number, Incorrect DFI Account Number, and Incorrect Transaction Code",
+	"C08": "Incorrect Receiving DFI Identification (IAT only)",
+	"C09": "Incorrect Individual Identification Number",
+	"C10": "Incorrect Company Name",
+	"C11": "Incorrect Company Identification",
+	"C12": "Incorrect Company Name and Company Identification",
+	"C13": "Addenda Format Error",
+	"C14": "Incorrect SEC Code for Outbound International Payment",
+}
+
+// Receiver holds the receiver details that a notification of change can correct.
+// Only the fields covered by the change code are used.
+type Receiver struct {
+	RoutingNumber             string // 9 digit routing number (C02, C03, C07)
+	DFIAccountNumber          string // C01, C03, C06, C07
+	TransactionCode           int    // C05, C06, C07
+	IndividualName            string // C04
+	IndividualIDNumber        string // C09
+	ForeignRDFIIdentification string // C08
+	CompanyName               string // C10, C12
+	CompanyIdentification     string // C11, C12
+}
+
+// ChangeCodeDescription returns the description of a notification of change code (C01 to C14)
+func ChangeCodeDescription(code string) (string, bool) {
+	description, ok := changeCodes[code]
+	return description, ok
+}
+
+// NewNOCEntry builds a notification of change entry for the original entry, carrying the corrected receiver
+// details for the change code in a notification of change addenda record (type 98).
+// The entry must be sent in a COR batch, and its TraceNumber must still be set with SetTraceNumber.
+func NewNOCEntry(original *NachaEntry, changeCode string, corrected Receiver) (*NachaEntry, error) {
+	correctedData, err := formatCorrectedData(changeCode, corrected)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionCode, ok := returnTransactionCode(original.TransactionCode)
+	if !ok {
+		return nil, errors.New("TransactionCode of the original entry cannot be corrected")
+	}
+	if len(original.TraceNumber) != 15 {
+		return nil, errors.New("TraceNumber of the original entry must be 15 characters")
+	}
+
+	odfiId := original.TraceNumber[:8]
+	checkDigit, err := routing.CheckDigit(odfiId)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &NachaEntry{}
+	entry.Default()
+	entry.TransactionCode = transactionCode
+	entry.ReceivingDFIIdentification = odfiId
+	entry.CheckDigit = strconv.Itoa(checkDigit)
+	entry.DFIAccountNumber = original.DFIAccountNumber
+	entry.Amount = Amount(0).format(10)
+	entry.IndividualIDNumber = original.IndividualIDNumber
+	entry.IndividualName = original.IndividualName
+	entry.DiscretionaryData = original.DiscretionaryData
+
+	addenda := &NachaAddenda{}
+	addenda.Default()
+	addenda.AddendaTypeCode = "98"
+	addenda.PaymentRelatedInformation = changeCode + original.TraceNumber + util.ToFixedWidthString("", 6, false) +
+		original.ReceivingDFIIdentification + correctedData + util.ToFixedWidthString("", 19, false)
+	addenda.SetAddendaTraceNumber("")
+
+	entry.Addenda = append(entry.Addenda, addenda)
+	entry.AddendaRecordIndicator = "1"
+	return entry, nil
+}
+
+// isNOC reports whether the addenda is a notification of change addenda record
+func (a *NachaAddenda) isNOC() bool {
+	return a.AddendaTypeCode == "98"
+}
+
+// ChangeCode returns the Change Code of a notification of change addenda record
+func (a *NachaAddenda) ChangeCode() string {
+	return a.returnField(0, 3)
+}
+
+// CorrectedData returns the raw Corrected Data of a notification of change addenda record
+func (a *NachaAddenda) CorrectedData() string {
+	return a.returnField(32, 61)
+}
+
+// Correction decodes the Corrected Data of a notification of change addenda record.
+// Only the fields covered by the change code are set.
+func (a *NachaAddenda) Correction() (Receiver, error) {
+	data := a.CorrectedData()
+	if len(data) != 29 {
+		return Receiver{}, errors.New("CorrectedData must be 29 characters")
+	}
+
+	var receiver Receiver
+	var err error
+
+	switch a.ChangeCode() {
+	case "C01":
+		receiver.DFIAccountNumber = strings.TrimSpace(data[:17])
+	case "C02":
+		receiver.RoutingNumber = data[:9]
+	case "C03":
+		receiver.RoutingNumber = data[:9]
+		receiver.DFIAccountNumber = strings.TrimSpace(data[12:29])
+	case "C04":
+		receiver.IndividualName = strings.TrimSpace(data[:22])
+	case "C05":
+		receiver.TransactionCode, err = parseIntField("TransactionCode", data[:2])
+	case "C06":
+		receiver.DFIAccountNumber = strings.TrimSpace(data[:17])
+		receiver.TransactionCode, err = parseIntField("TransactionCode", data[20:22])
+	case "C07":
+		receiver.RoutingNumber = data[:9]
+		receiver.DFIAccountNumber = strings.TrimSpace(data[9:26])
+		receiver.TransactionCode, err = parseIntField("TransactionCode", data[26:28])
+	case "C08":
+		receiver.ForeignRDFIIdentification = strings.TrimSpace(data)
+	case "C09":
+		receiver.IndividualIDNumber = strings.TrimSpace(data[:22])
+	case "C10":
+		receiver.CompanyName = strings.TrimSpace(data[:16])
+	case "C11":
+		receiver.CompanyIdentification = strings.TrimSpace(data[:10])
+	case "C12":
+		receiver.CompanyName = strings.TrimSpace(data[:16])
+		receiver.CompanyIdentification = strings.TrimSpace(data[16:26])
+	case "C13", "C14":
+		return Receiver{}, errors.New("change code " + a.ChangeCode() + " does not correct receiver details")
+	default:
+		return Receiver{}, errors.New("ChangeCode must be a valid change code (C01 to C14)")
+	}
+
+	if err != nil {
+		return Receiver{}, err
+	}
+	if receiver.RoutingNumber != "" {
+		if err := routing.Validate(receiver.RoutingNumber); err != nil {
+			return Receiver{}, err
+		}
+	}
+
+	return receiver, nil
+}
+
+// ApplyCorrection updates the stored receiver with the corrected data of a notification of change addenda record.
+// Only the fields covered by the change code are changed.
+func (a *NachaAddenda) ApplyCorrection(receiver *Receiver) error {
+	corrected, err := a.Correction()
+	if err != nil {
+		return err
+	}
+
+	if corrected.RoutingNumber != "" {
+		receiver.RoutingNumber = corrected.RoutingNumber
+	}
+	if corrected.DFIAccountNumber != "" {
+		receiver.DFIAccountNumber = corrected.DFIAccountNumber
+	}
+	if corrected.TransactionCode != 0 {
+		receiver.TransactionCode = corrected.TransactionCode
+	}
+	if corrected.IndividualName != "" {
+		receiver.IndividualName = corrected.IndividualName
+	}
+	if corrected.IndividualIDNumber != "" {
+		receiver.IndividualIDNumber = corrected.IndividualIDNumber
+	}
+	if corrected.ForeignRDFIIdentification != "" {
+		receiver.ForeignRDFIIdentification = corrected.ForeignRDFIIdentification
+	}
+	if corrected.CompanyName != "" {
+		receiver.CompanyName = corrected.CompanyName
+	}
+	if corrected.CompanyIdentification != "" {
+		receiver.CompanyIdentification = corrected.CompanyIdentification
+	}
+
+	return nil
+}
+
+// formatCorrectedData formats the corrected receiver details in the 29 character layout of the change code
+func formatCorrectedData(changeCode string, corrected Receiver) (string, error) {
+	if _, ok := changeCodes[changeCode]; !ok {
+		return "", errors.New("ChangeCode must be a valid change code (C01 to C14)")
+	}
+
+	needsRouting := changeCode == "C02" || changeCode == "C03" || changeCode == "C07"
+	needsAccount := changeCode == "C01" || changeCode == "C03" || changeCode == "C06" || changeCode == "C07"
+	needsTransactionCode := changeCode == "C05" || changeCode == "C06" || changeCode == "C07"
+	needsCompanyName := changeCode == "C10" || changeCode == "C12"
+	needsCompanyIdentification := changeCode == "C11" || changeCode == "C12"
+
+	if needsRouting {
+		if err := routing.Validate(corrected.RoutingNumber); err != nil {
+			return "", err
+		}
+	}
+	if needsAccount && (corrected.DFIAccountNumber == "" || len(corrected.DFIAccountNumber) > 17) {
+		return "", errors.New("DFIAccountNumber must be between 1 and 17 characters")
+	}
+	if _, ok := LookupTransactionCode(corrected.TransactionCode); needsTransactionCode && !ok {
+		return "", errors.New("TransactionCode must be a valid transaction code")
+	}
+	if changeCode == "C04" && (corrected.IndividualName == "" || len(corrected.IndividualName) > 22) {
+		return "", errors.New("IndividualName must be between 1 and 22 characters")
+	}
+	if changeCode == "C08" && (corrected.ForeignRDFIIdentification == "" || len(corrected.ForeignRDFIIdentification) > 29) {
+		return "", errors.New("ForeignRDFIIdentification must be between 1 and 29 characters")
+	}
+	if changeCode == "C09" && (corrected.IndividualIDNumber == "" || len(corrected.IndividualIDNumber) > 22) {
+		return "", errors.New("IndividualIDNumber must be between 1 and 22 characters")
+	}
+	if needsCompanyName && (corrected.CompanyName == "" || len(corrected.CompanyName) > 16) {
+		return "", errors.New("CompanyName must be between 1 and 16 characters")
+	}
+	if needsCompanyIdentification && (corrected.CompanyIdentification == "" || len(corrected.CompanyIdentification) > 10) {
+		return "", errors.New("CompanyIdentification must be between 1 and 10 characters")
+	}
+
+	account := util.ToFixedWidthString(corrected.DFIAccountNumber, 17, false)
+	transactionCode := strconv.Itoa(corrected.TransactionCode)
+
+	var data string
+	switch changeCode {
+	case "C01":
+		data = account
+	case "C02":
+		data = corrected.RoutingNumber
+	case "C03":
+		data = corrected.RoutingNumber + util.ToFixedWidthString("", 3, false) + account
+	case "C04":
+		data = strings.ToUpper(corrected.IndividualName)
+	case "C05":
+		data = transactionCode
+	case "C06":
+		data = account + util.ToFixedWidthString("", 3, false) + transactionCode
+	case "C07":
+		data = corrected.RoutingNumber + account + transactionCode
+	case "C08":
+		data = corrected.ForeignRDFIIdentification
+	case "C09":
+		data = corrected.IndividualIDNumber
+	case "C10":
+		data = strings.ToUpper(corrected.CompanyName)
+	case "C11":
+		data = corrected.CompanyIdentification
+	case "C12":
+		data = util.ToFixedWidthString(strings.ToUpper(corrected.CompanyName), 16, false) + corrected.CompanyIdentification
+	}
+
+	return util.ToFixedWidthString(data, 29, false), nil
+}
+
+// validateNOC checks the fields of a notification of change addenda record
+func validateNOC(v *validator, a *NachaAddenda) {
+	if _, ok := changeCodes[a.ChangeCode()]; !ok {
+		v.add("PaymentRelatedInformation", "Change Code must be a valid change code (C01 to C14)")
+	}
+	if !isDigits(a.OriginalEntryTraceNumber()) {
+		v.add("PaymentRelatedInformation", "Original Entry Trace Number must be 15 digits")
+	}
+	if !isDigits(a.OriginalRDFIIdentification()) {
+		v.add("PaymentRelatedInformation", "Original Receiving DFI Identification must be 8 digits")
+	}
+	if strings.TrimSpace(a.CorrectedData()) == "" && a.ChangeCode() != "C13" && a.ChangeCode() != "C14" {
+		v.add("PaymentRelatedInformation", "Corrected Data is required")
+	}
+	if !isDigits(a.AddendaTraceNumber()) {
+		v.add("EntryDetailSequenceNumber", "Trace Number must be 15 digits")
+	}
+}
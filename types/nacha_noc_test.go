@@ -0,0 +1,182 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newNOCTestFile returns a generated file with a COR batch correcting the first entry of a PPD debit batch
+func newNOCTestFile(t *testing.T, changeCode string, corrected Receiver) (*NachaFile, *NachaEntry) {
+	t.Helper()
+
+	original := addTestBatch(t, newTestFile(t), "PPD", 225, 27).Entries[0]
+	entry, err := NewNOCEntry(original, changeCode, corrected)
+	if err != nil {
+		t.Fatalf("NewNOCEntry() error = %v", err)
+	}
+
+	file := newTestFile(t)
+	batch := addTestBatch(t, file, "COR", 225)
+	must(t, batch.Header.SetODFIIdentification("02100002"))
+	must(t, entry.SetTraceNumber("02100002", 1))
+	batch.Entries = append(batch.Entries, entry)
+	must(t, file.GenerateFile())
+	return file, original
+}
+
+func TestNewNOCEntry(t *testing.T) {
+	tests := []struct {
+		changeCode string
+		corrected  Receiver
+		want       Receiver // Receiver decoded by Correction
+		wantData   string   // Corrected Data of the addenda record
+	}{
+		{
+			changeCode: "C01", corrected: Receiver{DFIAccountNumber: "18076850"},
+			want: Receiver{DFIAccountNumber: "18076850"}, wantData: "18076850",
+		},
+		{
+			changeCode: "C03", corrected: Receiver{RoutingNumber: "011000015", DFIAccountNumber: "18076850"},
+			want: Receiver{RoutingNumber: "011000015", DFIAccountNumber: "18076850"}, wantData: "011000015   18076850",
+		},
+		{
+			changeCode: "C04", corrected: Receiver{IndividualName: "Jane Doe"},
+			want: Receiver{IndividualName: "JANE DOE"}, wantData: "JANE DOE",
+		},
+		{
+			changeCode: "C07", corrected: Receiver{RoutingNumber: "011000015", DFIAccountNumber: "18076850", TransactionCode: 37},
+			want: Receiver{RoutingNumber: "011000015", DFIAccountNumber: "18076850", TransactionCode: 37}, wantData: "01100001518076850         37",
+		},
+		{
+			changeCode: "C10", corrected: Receiver{CompanyName: "ABC Corp"},
+			want: Receiver{CompanyName: "ABC CORP"}, wantData: "ABC CORP",
+		},
+		{
+			changeCode: "C11", corrected: Receiver{CompanyIdentification: "9988776655"},
+			want: Receiver{CompanyIdentification: "9988776655"}, wantData: "9988776655",
+		},
+		{
+			changeCode: "C12", corrected: Receiver{CompanyName: "ABC Corp", CompanyIdentification: "9988776655"},
+			want: Receiver{CompanyName: "ABC CORP", CompanyIdentification: "9988776655"}, wantData: "ABC CORP        9988776655",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.changeCode, func(t *testing.T) {
+			file, original := newNOCTestFile(t, tt.changeCode, tt.corrected)
+			entry := file.Batches[0].Entries[0]
+
+			if entry.TransactionCode != "26" || entry.RoutingNumber() != "011000015" || entry.Amount != "0000000000" || len(entry.Addenda) != 1 {
+				t.Fatalf("NewNOCEntry() = %q with %d addenda records", entry.String(), len(entry.Addenda))
+			}
+
+			addenda := entry.Addenda[0]
+			if addenda.AddendaTypeCode != "98" || addenda.ChangeCode() != tt.changeCode || addenda.OriginalEntryTraceNumber() != original.TraceNumber ||
+				addenda.OriginalRDFIIdentification() != "02100002" || addenda.AddendaTraceNumber() != entry.TraceNumber {
+				t.Errorf("notification of change addenda = %q", addenda.String())
+			}
+			if got := strings.TrimRight(addenda.CorrectedData(), " "); got != tt.wantData {
+				t.Errorf("CorrectedData() = %q, want %q", got, tt.wantData)
+			}
+
+			got, err := addenda.Correction()
+			if err != nil || got != tt.want {
+				t.Errorf("Correction() = %+v, %v, want %+v", got, err, tt.want)
+			}
+			if err := file.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNewNOCEntryErrors(t *testing.T) {
+	tests := []struct {
+		changeCode string
+		corrected  Receiver
+		wantErr    string
+	}{
+		{changeCode: "C99", wantErr: "ChangeCode must be a valid change code (C01 to C14)"},
+		{changeCode: "C02", corrected: Receiver{RoutingNumber: "011000016"}, wantErr: "routing number check digit must be 5"},
+		{changeCode: "C05", corrected: Receiver{TransactionCode: 25}, wantErr: "TransactionCode must be a valid transaction code"},
+		{changeCode: "C09", wantErr: "IndividualIDNumber must be between 1 and 22 characters"},
+		{changeCode: "C10", wantErr: "CompanyName must be between 1 and 16 characters"},
+		{changeCode: "C11", corrected: Receiver{CompanyIdentification: "99887766554"}, wantErr: "CompanyIdentification must be between 1 and 10 characters"},
+		{changeCode: "C12", corrected: Receiver{CompanyName: "ABC Corp"}, wantErr: "CompanyIdentification must be between 1 and 10 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.changeCode, func(t *testing.T) {
+			original := addTestBatch(t, newTestFile(t), "PPD", 225, 27).Entries[0]
+			if _, err := NewNOCEntry(original, tt.changeCode, tt.corrected); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("NewNOCEntry() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyCorrection(t *testing.T) {
+	file, _ := newNOCTestFile(t, "C12", Receiver{CompanyName: "ABC Corp", CompanyIdentification: "9988776655"})
+
+	receiver := Receiver{RoutingNumber: "021000021", DFIAccountNumber: "29079117", CompanyName: "ABC COMPANY", CompanyIdentification: "1122334455"}
+	must(t, file.Batches[0].Entries[0].Addenda[0].ApplyCorrection(&receiver))
+
+	want := Receiver{RoutingNumber: "021000021", DFIAccountNumber: "29079117", CompanyName: "ABC CORP", CompanyIdentification: "9988776655"}
+	if receiver != want {
+		t.Errorf("ApplyCorrection() = %+v, want %+v", receiver, want)
+	}
+}
+
+func TestValidateNOC(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *NachaEntry)
+		field  string // Field of the expected ValidationError
+		rule   string // Part of the rule of the expected ValidationError
+	}{
+		{
+			name: "change code",
+			modify: func(e *NachaEntry) {
+				e.Addenda[0].PaymentRelatedInformation = "C99" + e.Addenda[0].PaymentRelatedInformation[3:]
+			},
+			field: "PaymentRelatedInformation", rule: "Change Code must be a valid change code",
+		},
+		{
+			name: "corrected data",
+			modify: func(e *NachaEntry) {
+				a := e.Addenda[0]
+				a.PaymentRelatedInformation = a.PaymentRelatedInformation[:32] + strings.Repeat(" ", 29) + a.PaymentRelatedInformation[61:]
+			},
+			field: "PaymentRelatedInformation", rule: "Corrected Data is required",
+		},
+		{
+			name:   "amount",
+			modify: func(e *NachaEntry) { must(t, e.SetAmount(Dollars(1, 0))) },
+			field:  "Amount", rule: "COR entries must have a zero amount",
+		},
+		{
+			name:   "transaction code",
+			modify: func(e *NachaEntry) { e.TransactionCode = "27" },
+			field:  "TransactionCode", rule: "COR entries must use a return or notification of change transaction code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, _ := newNOCTestFile(t, "C10", Receiver{CompanyName: "ABC Corp"})
+			tt.modify(file.Batches[0].Entries[0])
+
+			var errs ValidationErrors
+			if err := file.Validate(); !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			for _, e := range errs {
+				if e.Field == tt.field && strings.Contains(e.Rule, tt.rule) {
+					return
+				}
+			}
+			t.Errorf("Validate() error =\n%v\nwant %s: %s", errs, tt.field, tt.rule)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rashintha/nacha/routing"
+	"github.com/rashintha/nacha/util"
+)
+
+// AccountType is the type of the account an entry is posted to
+type AccountType string
+
+const (
+	AccountTypeChecking      AccountType = "checking"
+	AccountTypeSavings       AccountType = "savings"
+	AccountTypeGeneralLedger AccountType = "general ledger"
+	AccountTypeLoan          AccountType = "loan"
+)
+
+// NachaOffset describes the settlement account used to balance a batch with an offsetting entry
+type NachaOffset struct {
+	RoutingNumber    string      // 9 digit routing number of the settlement account
+	DFIAccountNumber string      // Settlement account number
+	AccountType      AccountType // Checking, Savings, General Ledger or Loan
+	Name             string      // Name carried in the IndividualName of the offset entry
+}
+
+// transactionCode returns the transaction code of a debit or a credit to the offset account.
+// Loan accounts can only be debited by reversals, so they cannot take a debit offset.
+func (o *NachaOffset) transactionCode(debit bool) (string, error) {
+	if _, ok := liveTransactionCode(o.AccountType, false); !ok {
+		return "", errors.New("offset AccountType must be checking, savings, general ledger or loan")
+	}
+
+	code, ok := liveTransactionCode(o.AccountType, debit)
+	if !ok {
+		return "", errors.New("offset entries cannot debit " + string(o.AccountType) + " accounts")
+	}
+
+	return code, nil
+}
+
+// isOffsetEntry reports whether the entry is posted to the offset settlement account
+func (o *NachaOffset) isOffsetEntry(entry *NachaEntry) bool {
+	return o != nil && entry.RoutingNumber() == o.RoutingNumber &&
+		strings.TrimSpace(entry.DFIAccountNumber) == strings.TrimSpace(o.DFIAccountNumber)
+}
+
+// GenerateOffset balances the batch with an entry against the offset settlement account, so the batch nets to zero.
+// A debit offset is added for batches with more credits, and a credit offset for batches with more debits.
+// The ServiceClassCode is set to 200 as the batch then mixes debits and credits.
+// Offset entries added by a previous call are replaced and keep their trace number, so GenerateOffset can be called
+// again after the entries change, and a nil offset removes them.
+// Entries posted to the offset settlement account are treated as offset entries too, so the offset of a parsed
+// balanced file is replaced rather than added a second time. A nil offset cannot recognize those entries,
+// and only removes the offset entries added by GenerateOffset.
+func (b *NachaBatch) GenerateOffset(offset *NachaOffset) error {
+	var previous *NachaEntry
+	entries := b.Entries[:0]
+	for _, entry := range b.Entries {
+		if entry.offset || offset.isOffsetEntry(entry) {
+			previous = entry
+		} else {
+			entries = append(entries, entry)
+		}
+	}
+	b.Entries = entries
+
+	if offset == nil {
+		return nil
+	}
+	switch b.Header.StandardEntryClassCode {
+	case "IAT", "COR":
+		return errors.New("offset entries cannot be added to " + b.Header.StandardEntryClassCode + " batches")
+	case "TEL":
+		return errors.New("offset entries cannot be added to TEL batches, which can only contain debits")
+	}
+
+	rdfiId, checkDigit, err := routing.Split(offset.RoutingNumber)
+	if err != nil {
+		return err
+	}
+	if _, err := offset.transactionCode(false); err != nil {
+		return err
+	}
+	if offset.DFIAccountNumber == "" {
+		return errors.New("offset DFIAccountNumber cannot be empty")
+	}
+	if offset.Name == "" {
+		return errors.New("offset Name cannot be empty")
+	}
+
+	_, _, totalDebits, totalCredits := b.totals()
+	net := totalCredits - totalDebits
+	if net == 0 {
+		return nil
+	}
+
+	transactionCode, err := offset.transactionCode(net > 0)
+	if err != nil {
+		return err
+	}
+
+	entry := &NachaEntry{offset: true}
+	entry.Default()
+	entry.TransactionCode = transactionCode
+	if net < 0 {
+		net = -net
+	}
+	entry.ReceivingDFIIdentification = rdfiId
+	entry.CheckDigit = checkDigit
+	if err := entry.SetDFIAccountNumber(offset.DFIAccountNumber); err != nil {
+		return err
+	}
+	if err := entry.SetAmount(net); err != nil {
+		return err
+	}
+	entry.IndividualIDNumber = util.ToFixedWidthString("", 15, false)
+	if b.Header.StandardEntryClassCode == "CTX" {
+		_ = entry.SetNumberOfAddendaRecords(0)
+		_ = entry.SetReceivingCompanyName(offset.Name)
+	} else {
+		entry.IndividualName = util.ToFixedWidthString(strings.ToUpper(offset.Name), 22, false)
+	}
+	if b.Header.StandardEntryClassCode == "WEB" {
+		_ = entry.SetPaymentTypeCode("S")
+	}
+	sequence := b.nextTraceSequence()
+	if previous != nil && strings.HasPrefix(previous.TraceNumber, b.Header.ODFIIdentification) {
+		if previousSequence, err := previous.TraceSequence(); err == nil {
+			sequence = previousSequence
+		}
+	}
+	if err := entry.SetTraceNumber(b.Header.ODFIIdentification, sequence); err != nil {
+		return err
+	}
+
+	b.Entries = append(b.Entries, entry)
+	return b.Header.SetServiceClassCode(200)
+}
+
+// nextTraceSequence returns the sequence number following the highest trace number of the batch entries
+func (b *NachaBatch) nextTraceSequence() int {
+	next := 1
+	for _, entry := range b.Entries {
+		if sequence, err := entry.TraceSequence(); err == nil && sequence >= next {
+			next = sequence + 1
+		}
+	}
+
+	return next
+}
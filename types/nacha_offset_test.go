@@ -0,0 +1,68 @@
+package types
+
+import "testing"
+
+func TestGenerateOffset(t *testing.T) {
+	offset := &NachaOffset{
+		RoutingNumber:    "011000015",
+		DFIAccountNumber: "123456789",
+		AccountType:      AccountTypeChecking,
+		Name:             "ABC Company",
+	}
+
+	tests := []struct {
+		name             string
+		sec              string
+		serviceClassCode int
+		transactionCodes []int
+		wantCode         string // TransactionCode of the offset entry
+		wantErr          string
+	}{
+		{name: "PPD credits", sec: "PPD", serviceClassCode: 220, transactionCodes: []int{22, 32}, wantCode: "27"},
+		{name: "PPD debits", sec: "PPD", serviceClassCode: 225, transactionCodes: []int{27, 37}, wantCode: "22"},
+		{name: "CCD credits", sec: "CCD", serviceClassCode: 220, transactionCodes: []int{22}, wantCode: "27"},
+		{name: "WEB credits", sec: "WEB", serviceClassCode: 220, transactionCodes: []int{22}, wantCode: "27"},
+		{name: "WEB debits", sec: "WEB", serviceClassCode: 225, transactionCodes: []int{27}, wantCode: "22"},
+		{name: "CTX credits", sec: "CTX", serviceClassCode: 220, transactionCodes: []int{22}, wantCode: "27"},
+		{name: "TEL debits", sec: "TEL", serviceClassCode: 225, transactionCodes: []int{27}, wantErr: "offset entries cannot be added to TEL batches, which can only contain debits"},
+		{name: "COR", sec: "COR", serviceClassCode: 220, wantErr: "offset entries cannot be added to COR batches"},
+		{name: "IAT", sec: "IAT", serviceClassCode: 220, wantErr: "offset entries cannot be added to IAT batches"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			batch := addTestBatch(t, file, tt.sec, tt.serviceClassCode, tt.transactionCodes...)
+			file.Offset = offset
+
+			err := file.GenerateFile()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("GenerateFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateFile() error = %v", err)
+			}
+
+			last := batch.Entries[len(batch.Entries)-1]
+			if !last.offset || last.TransactionCode != tt.wantCode {
+				t.Errorf("offset entry TransactionCode = %q, want %q", last.TransactionCode, tt.wantCode)
+			}
+			if batch.Control.TotalDebits != batch.Control.TotalCredits {
+				t.Errorf("batch is not balanced: debits %s, credits %s", batch.Control.TotalDebits, batch.Control.TotalCredits)
+			}
+			if batch.Header.ServiceClassCode != "200" {
+				t.Errorf("ServiceClassCode = %q, want \"200\"", batch.Header.ServiceClassCode)
+			}
+			if err := file.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			if err := file.GenerateFile(); err != nil || len(batch.Entries) != len(tt.transactionCodes)+1 {
+				t.Errorf("GenerateFile() again = %v with %d entries, want a single offset entry", err, len(batch.Entries))
+			}
+		})
+	}
+}
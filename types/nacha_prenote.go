@@ -0,0 +1,60 @@
+package types
+
+import "errors"
+
+// IsPrenote reports whether the TransactionCode is a prenotification
+func (e *NachaEntry) IsPrenote() bool {
+	return transactionCodes[e.TransactionCode].Prenote
+}
+
+// Prenote builds a prenotification batch for the entries of the batch.
+// The prenote batch has the same header with a BatchNumber of 1, and an entry for every receiver with a zero amount
+// and the prenote transaction code of the receiver's account. Offset entries are left out.
+// The prenote entries get new trace numbers following the highest one used by the batch for its ODFI, so they never
+// repeat the trace numbers of the live entries. Add a Sequencer to the prenote file to renumber the batches and
+// trace numbers when it holds several prenote batches.
+func (b *NachaBatch) Prenote() (*NachaBatch, error) {
+	if b.Header.StandardEntryClassCode == "COR" {
+		return nil, errors.New("prenotes cannot be built from COR batches")
+	}
+
+	prenote := &NachaBatch{Header: b.Header}
+	prenote.Control.Default()
+	if err := prenote.Header.SetBatchNumber(1); err != nil {
+		return nil, err
+	}
+
+	sequences := make(map[string]int)
+	lastTraceSequences(sequences, b.Entries)
+	odfiId := prenote.Header.ODFIIdentification
+
+	for _, entry := range b.Entries {
+		if entry.offset {
+			continue
+		}
+
+		transactionCode, ok := prenoteTransactionCode(entry.TransactionCode)
+		if !ok {
+			return nil, errors.New("TransactionCode " + entry.TransactionCode + " has no prenote transaction code")
+		}
+
+		copied := entry.clone()
+		copied.TransactionCode = transactionCode
+		copied.Amount = Amount(0).format(10)
+		copied.TraceNumber = ""
+		sequences[odfiId]++
+		if err := copied.SetTraceNumber(odfiId, sequences[odfiId]); err != nil {
+			return nil, err
+		}
+		prenote.Entries = append(prenote.Entries, copied)
+	}
+
+	return prenote, nil
+}
+
+// validatePrenote checks that prenote entries carry a zero amount
+func validatePrenote(v *validator, entry *NachaEntry) {
+	if amount, err := entry.AmountCents(); err == nil && amount != 0 {
+		v.add("Amount", "must be zero for prenote entries")
+	}
+}
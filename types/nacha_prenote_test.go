@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPrenote(t *testing.T) {
+	file := newTestFile(t)
+	batch := addTestBatch(t, file, "PPD", 200, 22, 27, 32, 37, 42, 47, 52)
+	must(t, batch.Header.SetBatchNumber(4))
+	batch.Entries[1].NewAddenda().SetPaymentRelatedInformation("Invoice 42")
+	file.Offset = &NachaOffset{
+		RoutingNumber:    "011000015",
+		DFIAccountNumber: "123456789",
+		AccountType:      AccountTypeChecking,
+		Name:             "ABC Company",
+	}
+	must(t, file.GenerateFile())
+
+	prenote, err := batch.Prenote()
+	if err != nil {
+		t.Fatalf("Prenote() error = %v", err)
+	}
+	if prenote.Header.BatchNumber != "0000001" || prenote.Header.CompanyName != batch.Header.CompanyName {
+		t.Errorf("Prenote() header = %q", prenote.Header.String())
+	}
+
+	wantCodes := []string{"23", "28", "33", "38", "43", "48", "53"}
+	if len(prenote.Entries) != len(wantCodes) {
+		t.Fatalf("Prenote() built %d entries, want %d without the offset entry", len(prenote.Entries), len(wantCodes))
+	}
+	for i, entry := range prenote.Entries {
+		// The live entries and the offset entry use the sequence numbers 1 to 8
+		wantTrace := testODFI + fmt.Sprintf("%07d", 9+i)
+		if entry.TransactionCode != wantCodes[i] || entry.Amount != "0000000000" || entry.TraceNumber != wantTrace {
+			t.Errorf("Entries[%d] = code %q, amount %q, trace %q, want %q, 0, %q", i, entry.TransactionCode, entry.Amount, entry.TraceNumber, wantCodes[i], wantTrace)
+		}
+		if entry.DFIAccountNumber != batch.Entries[i].DFIAccountNumber || entry.IndividualName != batch.Entries[i].IndividualName {
+			t.Errorf("Entries[%d] receiver = %q, %q", i, entry.DFIAccountNumber, entry.IndividualName)
+		}
+	}
+	if a := prenote.Entries[1].Addenda[0]; a.EntryDetailSequenceNumber != "0000010" || batch.Entries[1].Addenda[0].EntryDetailSequenceNumber != "0000002" {
+		t.Errorf("Prenote() addenda linked to %q, live addenda to %q", a.EntryDetailSequenceNumber, batch.Entries[1].Addenda[0].EntryDetailSequenceNumber)
+	}
+	if batch.Entries[0].TraceNumber != testODFI+"0000001" || batch.Entries[0].TransactionCode != "22" {
+		t.Errorf("Prenote() changed the live entry to %q", batch.Entries[0].String())
+	}
+
+	prenoteFile := newTestFile(t)
+	prenoteFile.Batches = append(prenoteFile.Batches, prenote)
+	must(t, prenoteFile.GenerateFile())
+	if err := prenoteFile.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestPrenoteErrors(t *testing.T) {
+	tests := []struct {
+		name             string
+		sec              string
+		serviceClassCode int
+		transactionCodes []int
+		wantErr          string
+	}{
+		{name: "COR", sec: "COR", serviceClassCode: 220, wantErr: "prenotes cannot be built from COR batches"},
+		{name: "return entry", sec: "PPD", serviceClassCode: 225, transactionCodes: []int{26}, wantErr: "TransactionCode 26 has no prenote transaction code"},
+		{name: "reversal only loan debit", sec: "PPD", serviceClassCode: 225, transactionCodes: []int{55}, wantErr: "TransactionCode 55 has no prenote transaction code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := addTestBatch(t, newTestFile(t), tt.sec, tt.serviceClassCode, tt.transactionCodes...)
+			if _, err := batch.Prenote(); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Prenote() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RecordLength is the fixed length of every NACHA record
+const RecordLength = 94
+
+// checkRecord verifies that the record is RecordLength characters long and starts with the expected record type
+func checkRecord(record string, recordType string) error {
+	if len(record) != RecordLength {
+		return fmt.Errorf("record must be %d characters, got %d", RecordLength, len(record))
+	}
+	if record[:1] != recordType {
+		return errors.New("record type must be " + recordType)
+	}
+
+	return nil
+}
+
+// parseNumberField parses a zero padded numeric field
+func parseNumberField(field string, value string) (int64, error) {
+	if !isDigits(value) {
+		return 0, errors.New(field + " must be numeric")
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.New(field + " must be numeric")
+	}
+
+	return number, nil
+}
+
+// parseIntField parses a zero padded numeric field into an int
+func parseIntField(field string, value string) (int, error) {
+	number, err := parseNumberField(field, value)
+	return int(number), err
+}
+
+// parseDateField parses a date field in the YYMMDD format
+func parseDateField(field string, value string) (time.Time, error) {
+	date, err := time.Parse("060102", value)
+	if err != nil {
+		return time.Time{}, errors.New(field + " must be a valid date in the YYMMDD format")
+	}
+
+	return date, nil
+}
@@ -0,0 +1,244 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rashintha/nacha/routing"
+	"github.com/rashintha/nacha/util"
+)
+
+// Return addenda records (type 99) reuse the NachaAddenda fields with the following layout:
+//   PaymentRelatedInformation - Return Reason Code (Char Count: 3), Original Entry Trace Number (Char Count: 15),
+//                               Date of Death (Char Count: 6 | Format: YYMMDD | Optional), Original Receiving DFI Identification (Char Count: 8),
+//                               Addenda Information (Char Count: 44 | Optional) and the first 4 digits of the Trace Number
+//   AddendaSequenceNumber     - Digits 5 to 8 of the Trace Number
+//   EntryDetailSequenceNumber - Last 7 digits of the Trace Number
+
+// returnReasonCodes maps the NACHA return reason codes to their descriptions
+var returnReasonCodes = map[string]string{
+	"R01": "Insufficient Funds",
+	"R02": "Account Closed",
+	"R03": "No Account/Unable to Locate Account",
+	"R04": "Invalid Account Number Structure",
+	"R05": "Unauthorized Debit to Consumer Account Using Corporate SEC Code",
+	"R06": "Returned per ODFI's Request",
+	"R07": "Authorization Revoked by Customer",
+	"R08": "Payment Stopped",
+	"R09": "Uncollected Funds",
+	"R10": "Customer Advises Originator is Not Known to Receiver and/or Originator is Not Authorized by Receiver to Debit Receiver's Account",
+	"R11": "Customer Advises Entry Not in Accordance with the Terms of the Authorization",
+	"R12": "Account Sold to Another DFI",
+	"R13": "Invalid ACH Routing Number",
+	"R14": "Representative Payee Deceased or Unable to Continue in That Capacity",
+	"R15": "Beneficiary or Account Holder (Other Than a Representative Payee) Deceased",
+	"R16": "Account Frozen/Entry Returned per OFAC Instruction",
+	"R17": "File Record Edit Criteria/Entry with Invalid Account Number Initiated Under Questionable Circumstances",
+	"R18": "Improper Effective Entry Date",
+	"R19": "Amount Field Error",
+	"R20": "Non-Transaction Account",
+	"R21": "Invalid Company Identification",
+	"R22": "Invalid Individual ID Number",
+	"R23": "Credit Entry Refused by Receiver",
+	"R24": "Duplicate Entry",
+	"R25": "Addenda Error",
+	"R26": "Mandatory Field Error",
+	"R27": "Trace Number Error",
+	"R28": "Routing Number Check Digit Error",
+	"R29": "Corporate Customer Advises Not Authorized",
+	"R30": "RDFI Not Participant in Check Truncation Program",
+	"R31": "Permissible Return Entry (CCD and CTX only)",
+	"R32": "RDFI Non-Settlement",
+	"R33": "Return of XCK Entry",
+	"R34": "Limited Participation DFI",
+	"R35": "Return of Improper Debit Entry",
+	"R36": "Return of Improper Credit Entry",
+	"R37": "Source Document Presented for Payment",
+	"R38": "Stop Payment on Source Document",
+	"R39": "Improper Source Document/Source Document Presented for Payment",
+	"R40": "Return of ENR Entry by Federal Government Agency",
+	"R41": "Invalid Transaction Code (ENR only)",
+	"R42": "Routing Number/Check Digit Error (ENR only)",
+	"R43": "Invalid DFI Account Number (ENR only)",
+	"R44": "Invalid Individual ID Number/Identification Number (ENR only)",
+	"R45": "Invalid Individual Name/Company Name (ENR only)",
+	"R46": "Invalid Representative Payee Indicator (ENR only)",
+	"R47": "Duplicate Enrollment (ENR only)",
+	"R50": "State Law Affecting RCK Acceptance",
+	"R51": "Item Related to RCK Entry is Ineligible or RCK Entry is Improper",
+	"R52": "Stop Payment on Item Related to RCK Entry",
+	"R53": "Item and RCK Entry Presented for Payment",
+	"R61": "Misrouted Return",
+	"R62": "Return of Erroneous or Reversing Debit",
+	"R67": "Duplicate Return",
+	"R68": "Untimely Return",
+	"R69": "Field Error(s)",
+	"R70": "Permissible Return Entry Not Accepted/Return Not Requested by ODFI",
+	"R71": "Misrouted Dishonored Return",
+	"R72": "Untimely Dishonored Return",
+	"R73": "Timely Original Return",
+	"R74": "Corrected Return",
+	"R75": "Return Not a Duplicate",
+	"R76": "No Errors Found",
+	"R77": "Non-Acceptance of R62 Dishonored Return",
+	"R80": "IAT Entry Coding Error",
+	"R81": "Non-Participant in IAT Program",
+	"R82": "Invalid Foreign Receiving DFI Identification",
+	"R83": "Foreign Receiving DFI Unable to Settle",
+	"R84": "Entry Not Processed by Gateway",
+	"R85": "Incorrectly Coded Outbound International Payment",
+}
+
+// ReturnReasonDescription returns the description of a return reason code (R01 to R85)
+func ReturnReasonDescription(code string) (string, bool) {
+	description, ok := returnReasonCodes[code]
+	return description, ok
+}
+
+// NewReturnEntry builds a return entry for the original entry with the given return reason code.
+// The return is sent back to the ODFI that originated the entry, and carries a return addenda record (type 99).
+// dateOfDeath is only used with the R14 and R15 return reason codes and can be left as the zero time otherwise.
+// The TraceNumber of the return entry must still be set with SetTraceNumber.
+func NewReturnEntry(original *NachaEntry, reasonCode string, dateOfDeath time.Time) (*NachaEntry, error) {
+	if _, ok := returnReasonCodes[reasonCode]; !ok {
+		return nil, errors.New("ReturnReasonCode must be a valid return reason code (R01 to R85)")
+	}
+
+	transactionCode, ok := returnTransactionCode(original.TransactionCode)
+	if !ok {
+		return nil, errors.New("TransactionCode of the original entry cannot be returned")
+	}
+	if len(original.TraceNumber) != 15 {
+		return nil, errors.New("TraceNumber of the original entry must be 15 characters")
+	}
+
+	odfiId := original.TraceNumber[:8]
+	checkDigit, err := routing.CheckDigit(odfiId)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &NachaEntry{}
+	entry.Default()
+	entry.TransactionCode = transactionCode
+	entry.ReceivingDFIIdentification = odfiId
+	entry.CheckDigit = strconv.Itoa(checkDigit)
+	entry.DFIAccountNumber = original.DFIAccountNumber
+	entry.Amount = original.Amount
+	entry.IndividualIDNumber = original.IndividualIDNumber
+	entry.IndividualName = original.IndividualName
+	entry.DiscretionaryData = original.DiscretionaryData
+
+	addenda := &NachaAddenda{}
+	addenda.Default()
+	addenda.AddendaTypeCode = "99"
+	addenda.PaymentRelatedInformation = reasonCode + original.TraceNumber +
+		formatOptionalDate(dateOfDeath) + original.ReceivingDFIIdentification +
+		util.ToFixedWidthString("", 48, false)
+	addenda.SetAddendaTraceNumber("")
+
+	entry.Addenda = append(entry.Addenda, addenda)
+	entry.AddendaRecordIndicator = "1"
+	return entry, nil
+}
+
+// isReturn reports whether the addenda is a return addenda record
+func (a *NachaAddenda) isReturn() bool {
+	return a.AddendaTypeCode == "99"
+}
+
+// returnField returns a part of the PaymentRelatedInformation of a return addenda record
+func (a *NachaAddenda) returnField(start int, end int) string {
+	if len(a.PaymentRelatedInformation) != 80 {
+		return ""
+	}
+
+	return a.PaymentRelatedInformation[start:end]
+}
+
+// ReturnReasonCode returns the Return Reason Code of a return addenda record
+func (a *NachaAddenda) ReturnReasonCode() string {
+	return a.returnField(0, 3)
+}
+
+// OriginalEntryTraceNumber returns the Original Entry Trace Number of a return or notification of change addenda record
+func (a *NachaAddenda) OriginalEntryTraceNumber() string {
+	return a.returnField(3, 18)
+}
+
+// DateOfDeath returns the Date of Death of a return addenda record.
+// A blank Date of Death returns the zero time.
+func (a *NachaAddenda) DateOfDeath() (time.Time, error) {
+	date := a.returnField(18, 24)
+	if strings.TrimSpace(date) == "" {
+		return time.Time{}, nil
+	}
+
+	return parseDateField("DateOfDeath", date)
+}
+
+// OriginalRDFIIdentification returns the Original Receiving DFI Identification of a return or notification of change addenda record
+func (a *NachaAddenda) OriginalRDFIIdentification() string {
+	return a.returnField(24, 32)
+}
+
+// SetAddendaInformation sets the Addenda Information of a return addenda record
+// If the information is more than 44 characters, it will be truncated
+func (a *NachaAddenda) SetAddendaInformation(info string) error {
+	if len(a.PaymentRelatedInformation) != 80 {
+		return errors.New("PaymentRelatedInformation must be 80 characters")
+	}
+
+	a.PaymentRelatedInformation = a.PaymentRelatedInformation[:32] +
+		util.ToFixedWidthString(strings.ToUpper(info), 44, false) + a.PaymentRelatedInformation[76:]
+	return nil
+}
+
+// AddendaInformation returns the Addenda Information of a return addenda record
+func (a *NachaAddenda) AddendaInformation() string {
+	return strings.TrimSpace(a.returnField(32, 76))
+}
+
+// SetAddendaTraceNumber sets the 15 digit Trace Number carried in the last positions of return and notification
+// of change addenda records, which must match the TraceNumber of their entry
+func (a *NachaAddenda) SetAddendaTraceNumber(traceNumber string) {
+	trace := util.ToFixedWidthString(traceNumber, 15, false)
+	a.PaymentRelatedInformation = util.ToFixedWidthString(a.PaymentRelatedInformation, 80, false)[:76] + trace[:4]
+	a.AddendaSequenceNumber = trace[4:8]
+	a.EntryDetailSequenceNumber = trace[8:]
+}
+
+// AddendaTraceNumber returns the 15 digit Trace Number carried by return and notification of change addenda records
+func (a *NachaAddenda) AddendaTraceNumber() string {
+	return a.returnField(76, 80) + a.AddendaSequenceNumber + a.EntryDetailSequenceNumber
+}
+
+// formatOptionalDate formats the date as YYMMDD, or blanks for the zero time
+func formatOptionalDate(date time.Time) string {
+	if date.IsZero() {
+		return util.ToFixedWidthString("", 6, false)
+	}
+
+	return date.Format("060102")
+}
+
+// validateReturn checks the fields of a return addenda record
+func validateReturn(v *validator, a *NachaAddenda) {
+	if _, ok := returnReasonCodes[a.ReturnReasonCode()]; !ok {
+		v.add("PaymentRelatedInformation", "Return Reason Code must be a valid return reason code (R01 to R85)")
+	}
+	if !isDigits(a.OriginalEntryTraceNumber()) {
+		v.add("PaymentRelatedInformation", "Original Entry Trace Number must be 15 digits")
+	}
+	if _, err := a.DateOfDeath(); err != nil {
+		v.add("PaymentRelatedInformation", "Date of Death must be blank or a valid date in the YYMMDD format")
+	}
+	if !isDigits(a.OriginalRDFIIdentification()) {
+		v.add("PaymentRelatedInformation", "Original Receiving DFI Identification must be 8 digits")
+	}
+	if !isDigits(a.AddendaTraceNumber()) {
+		v.add("EntryDetailSequenceNumber", "Trace Number must be 15 digits")
+	}
+}
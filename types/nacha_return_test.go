@@ -0,0 +1,142 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newReturnTestFile returns a generated file with a batch returning the first entry of a PPD debit batch
+func newReturnTestFile(t *testing.T, reasonCode string, dateOfDeath time.Time) (*NachaFile, *NachaEntry) {
+	t.Helper()
+
+	original := addTestBatch(t, newTestFile(t), "PPD", 225, 27).Entries[0]
+	entry, err := NewReturnEntry(original, reasonCode, dateOfDeath)
+	if err != nil {
+		t.Fatalf("NewReturnEntry() error = %v", err)
+	}
+
+	file := newTestFile(t)
+	batch := addTestBatch(t, file, "PPD", 225)
+	must(t, batch.Header.SetODFIIdentification("02100002"))
+	must(t, entry.SetTraceNumber("02100002", 1))
+	batch.Entries = append(batch.Entries, entry)
+	must(t, file.GenerateFile())
+	return file, original
+}
+
+func TestNewReturnEntry(t *testing.T) {
+	file, original := newReturnTestFile(t, "R01", time.Time{})
+	entry := file.Batches[0].Entries[0]
+	must(t, entry.Addenda[0].SetAddendaInformation("Account overdrawn"))
+
+	if entry.TransactionCode != "26" || entry.RoutingNumber() != "011000015" || entry.Amount != original.Amount ||
+		entry.DFIAccountNumber != original.DFIAccountNumber || entry.IndividualName != original.IndividualName {
+		t.Errorf("NewReturnEntry() = %q", entry.String())
+	}
+	if len(entry.Addenda) != 1 || !entry.HasAddenda() {
+		t.Fatalf("NewReturnEntry() has %d addenda records, indicator %q", len(entry.Addenda), entry.AddendaRecordIndicator)
+	}
+
+	addenda := entry.Addenda[0]
+	if addenda.AddendaTypeCode != "99" || addenda.ReturnReasonCode() != "R01" || addenda.OriginalEntryTraceNumber() != original.TraceNumber ||
+		addenda.OriginalRDFIIdentification() != "02100002" || addenda.AddendaInformation() != "ACCOUNT OVERDRAWN" {
+		t.Errorf("return addenda = %q", addenda.String())
+	}
+	if date, err := addenda.DateOfDeath(); err != nil || !date.IsZero() {
+		t.Errorf("DateOfDeath() = %v, %v, want the zero time", date, err)
+	}
+	if addenda.AddendaTraceNumber() != entry.TraceNumber || len(addenda.String()) != 94 {
+		t.Errorf("AddendaTraceNumber() = %q, want %q", addenda.AddendaTraceNumber(), entry.TraceNumber)
+	}
+	if description, ok := ReturnReasonDescription(addenda.ReturnReasonCode()); !ok || description != "Insufficient Funds" {
+		t.Errorf("ReturnReasonDescription() = %q, %v", description, ok)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	file, _ = newReturnTestFile(t, "R15", time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC))
+	if date, err := file.Batches[0].Entries[0].Addenda[0].DateOfDeath(); err != nil || !date.Equal(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DateOfDeath() = %v, %v, want 2026-09-30", date, err)
+	}
+}
+
+func TestNewReturnEntryErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(e *NachaEntry)
+		reasonCode string
+		wantErr    string
+	}{
+		{name: "unknown reason code", modify: func(e *NachaEntry) {}, reasonCode: "R99", wantErr: "ReturnReasonCode must be a valid return reason code (R01 to R85)"},
+		{name: "lower case reason code", modify: func(e *NachaEntry) {}, reasonCode: "r01", wantErr: "ReturnReasonCode must be a valid return reason code (R01 to R85)"},
+		{name: "return entry", modify: func(e *NachaEntry) { e.TransactionCode = "26" }, reasonCode: "R01", wantErr: "TransactionCode of the original entry cannot be returned"},
+		{name: "short trace number", modify: func(e *NachaEntry) { e.TraceNumber = "0110000100" }, reasonCode: "R01", wantErr: "TraceNumber of the original entry must be 15 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := addTestBatch(t, newTestFile(t), "PPD", 225, 27).Entries[0]
+			tt.modify(original)
+
+			if _, err := NewReturnEntry(original, tt.reasonCode, time.Time{}); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("NewReturnEntry() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReturn(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(a *NachaAddenda)
+		rule   string // Part of the rule of the expected ValidationError
+	}{
+		{
+			name:   "reason code",
+			modify: func(a *NachaAddenda) { a.PaymentRelatedInformation = "R99" + a.PaymentRelatedInformation[3:] },
+			rule:   "Return Reason Code must be a valid return reason code",
+		},
+		{
+			name: "original entry trace number",
+			modify: func(a *NachaAddenda) {
+				a.PaymentRelatedInformation = a.PaymentRelatedInformation[:3] + "0110000100000X1" + a.PaymentRelatedInformation[18:]
+			},
+			rule: "Original Entry Trace Number must be 15 digits",
+		},
+		{
+			name: "date of death",
+			modify: func(a *NachaAddenda) {
+				a.PaymentRelatedInformation = a.PaymentRelatedInformation[:18] + "261399" + a.PaymentRelatedInformation[24:]
+			},
+			rule: "Date of Death must be blank or a valid date in the YYMMDD format",
+		},
+		{
+			name: "original receiving DFI identification",
+			modify: func(a *NachaAddenda) {
+				a.PaymentRelatedInformation = a.PaymentRelatedInformation[:24] + "        " + a.PaymentRelatedInformation[32:]
+			},
+			rule: "Original Receiving DFI Identification must be 8 digits",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, _ := newReturnTestFile(t, "R01", time.Time{})
+			tt.modify(file.Batches[0].Entries[0].Addenda[0])
+
+			var errs ValidationErrors
+			if err := file.Validate(); !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			for _, e := range errs {
+				if e.Record == 4 && e.Field == "PaymentRelatedInformation" && strings.Contains(e.Rule, tt.rule) {
+					return
+				}
+			}
+			t.Errorf("Validate() error =\n%v\nwant record 4 PaymentRelatedInformation: %s", errs, tt.rule)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// reversalServiceClassCodes maps the ServiceClassCode of a batch to the code of its reversal
+var reversalServiceClassCodes = map[string]string{
+	"200": "200",
+	"220": "225",
+	"225": "220",
+}
+
+// Reverse builds a new file that reverses the given entries of the file, or every entry when none are given.
+// Every batch holding a reversed entry gets a reversal batch with the same header data, the opposite
+// ServiceClassCode and a CompanyEntryDescription of REVERSAL. The reversing entries keep the amounts and receivers of
+// the originals with the debit and credit transaction codes swapped. Batch numbers are assigned from 1, and trace
+// numbers continue after the highest one used by the file for each ODFI, or come from a new sequencer sharing the
+// Store of the file Sequencer when one is set. The new file is generated so it is ready to be written.
+// Offset entries are not reversed, the batches are balanced again with the Offset of the original file or batch.
+// TEL batches and WEB credits without a Payment Type Code cannot be reversed, as the reversing entries would break the
+// rules of their Standard Entry Class Code.
+func (f *NachaFile) Reverse(entries ...*NachaEntry) (*NachaFile, error) {
+	reversal := &NachaFile{Header: f.Header, Offset: f.Offset}
+	reversal.Header.SetFileCreationDateToDefault()
+	reversal.Header.SetFileCreationTimeToDefault()
+	reversal.Control.Default()
+	if f.Sequencer != nil {
+		reversal.Sequencer = &NachaSequencer{Store: f.Sequencer.Store}
+	}
+
+	matched := make(map[*NachaEntry]bool)
+	sequences := make(map[string]int)
+	for _, batch := range f.Batches {
+		lastTraceSequences(sequences, batch.Entries)
+	}
+	for _, batch := range f.Batches {
+		reversed := &NachaBatch{Header: batch.Header, Offset: batch.Offset}
+		reversed.Control.Default()
+		if err := reversed.Header.SetCompanyEntryDescription("REVERSAL"); err != nil {
+			return nil, err
+		}
+		if code, ok := reversalServiceClassCodes[batch.Header.ServiceClassCode]; ok {
+			reversed.Header.ServiceClassCode = code
+		}
+
+		for _, entry := range batch.Entries {
+			if len(entries) > 0 && !slices.Contains(entries, entry) {
+				continue
+			}
+			matched[entry] = true
+			if entry.offset {
+				continue
+			}
+
+			if err := checkReversal(&batch.Header, entry); err != nil {
+				return nil, err
+			}
+			transactionCode, ok := reversalTransactionCode(entry.TransactionCode)
+			if !ok {
+				return nil, errors.New("TransactionCode " + entry.TransactionCode + " cannot be reversed")
+			}
+
+			copied := entry.clone()
+			copied.TransactionCode = transactionCode
+			copied.TraceNumber = ""
+			if reversal.Sequencer == nil {
+				odfiId := reversed.Header.ODFIIdentification
+				sequences[odfiId]++
+				if err := copied.SetTraceNumber(odfiId, sequences[odfiId]); err != nil {
+					return nil, err
+				}
+			}
+			reversed.Entries = append(reversed.Entries, copied)
+		}
+
+		if len(reversed.Entries) == 0 {
+			continue
+		}
+		if err := reversed.Header.SetBatchNumber(len(reversal.Batches) + 1); err != nil {
+			return nil, err
+		}
+		reversal.Batches = append(reversal.Batches, reversed)
+	}
+
+	var errs []error
+	for i, entry := range entries {
+		if !matched[entry] {
+			errs = append(errs, fmt.Errorf("entry %d with trace number %q does not belong to the file", i+1, entry.TraceNumber))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if len(reversal.Batches) == 0 {
+		return nil, errors.New("no entries to reverse")
+	}
+	if err := reversal.GenerateFile(); err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
+
+// checkReversal checks that the entry can be reversed within the rules of the Standard Entry Class Code of its batch
+func checkReversal(header *NachaBatchHeader, entry *NachaEntry) error {
+	switch header.StandardEntryClassCode {
+	case "COR":
+		return errors.New("COR entries cannot be reversed")
+	case "TEL":
+		return errors.New("TEL entries cannot be reversed, as TEL batches can only contain debits")
+	case "WEB":
+		if code := entry.PaymentTypeCode(); entry.IsCredit() && code != "R" && code != "S" {
+			return errors.New("WEB credits need a Payment Type Code to be reversed into WEB debits")
+		}
+	}
+
+	return nil
+}
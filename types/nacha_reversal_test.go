@@ -0,0 +1,146 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	file := newTestFile(t)
+	addTestBatch(t, file, "PPD", 225, 27, 37)
+	addTestBatch(t, file, "PPD", 220, 22, 32)
+	must(t, file.GenerateFile())
+
+	reversal, err := file.Reverse()
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	if err := reversal.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	want := []struct{ serviceClassCode, transactionCodes, traceNumbers string }{
+		{"220", "22 32", "011000010000005 011000010000006"},
+		{"225", "27 37", "011000010000007 011000010000008"},
+	}
+	if len(reversal.Batches) != len(want) {
+		t.Fatalf("Reverse() has %d batches, want %d", len(reversal.Batches), len(want))
+	}
+	for i, batch := range reversal.Batches {
+		var codes, traces []string
+		for _, entry := range batch.Entries {
+			codes = append(codes, entry.TransactionCode)
+			traces = append(traces, entry.TraceNumber)
+		}
+		if batch.Header.ServiceClassCode != want[i].serviceClassCode || strings.Join(codes, " ") != want[i].transactionCodes ||
+			strings.Join(traces, " ") != want[i].traceNumbers {
+			t.Errorf("batch %d = %s, %v, %v, want %s, %s, %s", i+1, batch.Header.ServiceClassCode, codes, traces,
+				want[i].serviceClassCode, want[i].transactionCodes, want[i].traceNumbers)
+		}
+		if description := strings.TrimSpace(batch.Header.CompanyEntryDescription); description != "REVERSAL" {
+			t.Errorf("batch %d CompanyEntryDescription = %q, want \"REVERSAL\"", i+1, description)
+		}
+	}
+	for _, batch := range file.Batches {
+		for _, entry := range batch.Entries {
+			for _, reversed := range reversal.Batches {
+				for _, reversing := range reversed.Entries {
+					if reversing.TraceNumber == entry.TraceNumber {
+						t.Errorf("reversing entry repeats the trace number %q of the original entry", entry.TraceNumber)
+					}
+				}
+			}
+		}
+	}
+	if reversal.Control.TotalDebits != file.Control.TotalCredits || reversal.Control.TotalCredits != file.Control.TotalDebits {
+		t.Errorf("Reverse() did not swap the file totals")
+	}
+}
+
+func TestReverseWithSequencer(t *testing.T) {
+	store := &MemorySequenceStore{}
+	file := newTestFile(t)
+	addTestBatch(t, file, "PPD", 225, 27)
+	addTestBatch(t, file, "PPD", 225, 27)
+	file.Sequencer = &NachaSequencer{Store: store}
+	must(t, file.GenerateFile())
+
+	reversal, err := file.Reverse()
+	if err != nil {
+		t.Fatalf("Reverse() error = %v", err)
+	}
+	if err := reversal.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if trace := reversal.Batches[1].Entries[0].TraceNumber; trace != "011000010000004" {
+		t.Errorf("TraceNumber = %q, want \"011000010000004\"", trace)
+	}
+}
+
+func TestReverseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		sec     string
+		codes   []int
+		entries func(f *NachaFile) []*NachaEntry
+		wantErr string
+	}{
+		{
+			name: "subset", sec: "PPD", codes: []int{27, 27},
+			entries: func(f *NachaFile) []*NachaEntry { return f.Batches[0].Entries[1:] },
+		},
+		{
+			name: "WEB credits with a Payment Type Code", sec: "WEB", codes: []int{22},
+		},
+		{
+			name: "WEB credits without a Payment Type Code", sec: "WEB", codes: []int{22},
+			entries: func(f *NachaFile) []*NachaEntry {
+				f.Batches[0].Entries[0].SetDiscretionaryDataToDefault()
+				return nil
+			},
+			wantErr: "WEB credits need a Payment Type Code to be reversed into WEB debits",
+		},
+		{
+			name: "TEL", sec: "TEL", codes: []int{27},
+			wantErr: "TEL entries cannot be reversed, as TEL batches can only contain debits",
+		},
+		{
+			name: "entry of another file", sec: "PPD", codes: []int{27},
+			entries: func(f *NachaFile) []*NachaEntry {
+				other := newTestFile(t)
+				return append(f.Batches[0].Entries, addTestBatch(t, other, "PPD", 225, 27).Entries[0])
+			},
+			wantErr: "entry 2 with trace number \"011000010000001\" does not belong to the file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			addTestBatch(t, file, tt.sec, 200, tt.codes...)
+			must(t, file.GenerateFile())
+
+			var entries []*NachaEntry
+			if tt.entries != nil {
+				entries = tt.entries(file)
+			}
+
+			reversal, err := file.Reverse(entries...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Reverse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reverse() error = %v", err)
+			}
+			if err := reversal.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if want := max(len(entries), 1); len(reversal.Batches[0].Entries) != want {
+				t.Errorf("Reverse() has %d entries, want %d", len(reversal.Batches[0].Entries), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rashintha/nacha/calendar"
+)
+
+// SameDayEntryLimit is the maximum amount of a single Same Day ACH entry ($1,000,000.00)
+const SameDayEntryLimit Amount = 100000000
+
+// SettlementWindow is a Federal Reserve Same Day ACH processing window.
+// Times are durations since midnight Eastern Time.
+type SettlementWindow struct {
+	Name       string
+	Deadline   time.Duration // Latest submission time for the window
+	Settlement time.Duration // Settlement time of the window
+}
+
+// Same Day ACH settlement windows of the Federal Reserve
+var (
+	SameDayWindow1 = SettlementWindow{Name: "1", Deadline: 10*time.Hour + 30*time.Minute, Settlement: 13 * time.Hour}
+	SameDayWindow2 = SettlementWindow{Name: "2", Deadline: 14*time.Hour + 45*time.Minute, Settlement: 17 * time.Hour}
+	SameDayWindow3 = SettlementWindow{Name: "3", Deadline: 16*time.Hour + 45*time.Minute, Settlement: 18 * time.Hour}
+)
+
+// SameDayWindows are the Same Day ACH settlement windows in the order they close
+var SameDayWindows = []SettlementWindow{SameDayWindow1, SameDayWindow2, SameDayWindow3}
+
+// easternTime returns the time zone the settlement windows are defined in.
+// It needs the zoneinfo database of the host, or the time/tzdata package imported by the program.
+func easternTime() (*time.Location, error) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, errors.New("cannot load the America/New_York time zone: " + err.Error())
+	}
+
+	return location, nil
+}
+
+// SetSameDay marks the batch for Same Day ACH settlement on the given date in the given settlement window.
+// The EffectiveEntryDate is set to the date, and the CompanyDescriptiveDate to SDHHMM with the settlement time of the window.
+func (h *NachaBatchHeader) SetSameDay(date time.Time, window SettlementWindow) {
+	h.EffectiveEntryDate = date.Format("060102")
+	h.CompanyDescriptiveDate = fmt.Sprintf("SD%02d%02d", int(window.Settlement.Hours()), int(window.Settlement.Minutes())%60)
+}
+
+// IsSameDay reports whether the batch is marked for Same Day ACH settlement by its CompanyDescriptiveDate
+func (h *NachaBatchHeader) IsSameDay() bool {
+	return strings.HasPrefix(h.CompanyDescriptiveDate, "SD")
+}
+
+// SameDayWindow returns the settlement window requested by the CompanyDescriptiveDate of a Same Day ACH batch
+func (h *NachaBatchHeader) SameDayWindow() (SettlementWindow, bool) {
+	if !h.IsSameDay() {
+		return SettlementWindow{}, false
+	}
+
+	for _, window := range SameDayWindows {
+		if h.CompanyDescriptiveDate[2:] == fmt.Sprintf("%02d%02d", int(window.Settlement.Hours()), int(window.Settlement.Minutes())%60) {
+			return window, true
+		}
+	}
+
+	return SettlementWindow{}, false
+}
+
+// QualifiesForSameDay checks whether the batch qualifies for Same Day ACH settlement in the window
+// when it is submitted at the given time, which must be on a banking day.
+// It returns nil when the batch qualifies, or the reason it does not, and an error when
+// the America/New_York time zone cannot be loaded.
+func (b *NachaBatch) QualifiesForSameDay(submission time.Time, window SettlementWindow) error {
+	if b.Header.StandardEntryClassCode == "IAT" {
+		return errors.New("IAT entries are not eligible for Same Day ACH")
+	}
+	for i, entry := range b.Entries {
+		if amount, err := entry.AmountCents(); err == nil && amount > SameDayEntryLimit {
+			return fmt.Errorf("entry %d amount %s exceeds the Same Day ACH limit of %s", i+1, amount, SameDayEntryLimit)
+		}
+	}
+
+	location, err := easternTime()
+	if err != nil {
+		return err
+	}
+
+	local := submission.In(location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	if !calendar.IsBankingDay(day) {
+		return fmt.Errorf("submission on %s is not on a banking day", local.Format("2006-01-02"))
+	}
+	if local.Sub(day) > window.Deadline {
+		return fmt.Errorf("submission at %s is after the deadline of Same Day ACH window %s", local.Format("15:04 MST"), window.Name)
+	}
+
+	effective, err := b.Header.EffectiveDate()
+	if err != nil {
+		return err
+	}
+	if effective.After(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)) {
+		return errors.New("EffectiveEntryDate is after the submission date, so the batch settles on a later day")
+	}
+
+	return nil
+}
+
+// validateSameDay checks the rules of Same Day ACH batches
+func validateSameDay(v *validator, header *NachaBatchHeader) {
+	if header.StandardEntryClassCode == "IAT" {
+		v.add("StandardEntryClassCode", "IAT entries are not eligible for Same Day ACH")
+	}
+}
+
+// validateSameDayEntry checks the rules of entries in Same Day ACH batches
+func validateSameDayEntry(v *validator, entry *NachaEntry) {
+	if amount, err := entry.AmountCents(); err == nil && amount > SameDayEntryLimit {
+		v.add("Amount", "Same Day ACH entries must not exceed "+SameDayEntryLimit.String())
+	}
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQualifiesForSameDay(t *testing.T) {
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("the America/New_York time zone is not available:", err)
+	}
+
+	tests := []struct {
+		name       string
+		effective  time.Time
+		submission time.Time
+		window     SettlementWindow
+		amount     Amount
+		sec        string
+		wantErr    string
+	}{
+		{
+			name:       "before the deadline",
+			effective:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 10, 16, 14, 0, 0, 0, eastern),
+			window:     SameDayWindow2,
+		},
+		{
+			name:       "submitted in UTC",
+			effective:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC),
+			window:     SameDayWindow1,
+		},
+		{
+			name:       "after the deadline",
+			effective:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 10, 16, 15, 0, 0, 0, eastern),
+			window:     SameDayWindow2,
+			wantErr:    "submission at 15:00 EDT is after the deadline of Same Day ACH window 2",
+		},
+		{
+			name:       "weekday holiday",
+			effective:  time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 11, 11, 9, 0, 0, 0, eastern),
+			window:     SameDayWindow1,
+			wantErr:    "submission on 2026-11-11 is not on a banking day",
+		},
+		{
+			name:       "weekend",
+			effective:  time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 10, 17, 9, 0, 0, 0, eastern),
+			window:     SameDayWindow1,
+			wantErr:    "submission on 2026-10-17 is not on a banking day",
+		},
+		{
+			name:       "future effective date",
+			effective:  time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 10, 16, 9, 0, 0, 0, eastern),
+			window:     SameDayWindow1,
+			wantErr:    "EffectiveEntryDate is after the submission date, so the batch settles on a later day",
+		},
+		{
+			name:       "entry limit",
+			effective:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 10, 16, 9, 0, 0, 0, eastern),
+			window:     SameDayWindow1,
+			amount:     SameDayEntryLimit + 1,
+			wantErr:    "entry 1 amount 1000000.01 exceeds the Same Day ACH limit of 1000000.00",
+		},
+		{
+			name:       "IAT",
+			effective:  time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			submission: time.Date(2026, 10, 16, 9, 0, 0, 0, eastern),
+			window:     SameDayWindow1,
+			sec:        "IAT",
+			wantErr:    "IAT entries are not eligible for Same Day ACH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := addTestBatch(t, newTestFile(t), "PPD", 225, 27)
+			batch.Header.SetSameDay(tt.effective, tt.window)
+			if tt.amount != 0 {
+				must(t, batch.Entries[0].SetAmount(tt.amount))
+			}
+			if tt.sec != "" {
+				batch.Header.StandardEntryClassCode = tt.sec
+			}
+
+			err := batch.QualifiesForSameDay(tt.submission, tt.window)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("QualifiesForSameDay() error = %v, want nil", err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("QualifiesForSameDay() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetSameDay(t *testing.T) {
+	header := NachaBatchHeader{}
+	header.SetSameDay(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), SameDayWindow3)
+
+	if header.CompanyDescriptiveDate != "SD1800" || header.EffectiveEntryDate != "261016" {
+		t.Errorf("SetSameDay() = %q, %q, want \"SD1800\", \"261016\"", header.CompanyDescriptiveDate, header.EffectiveEntryDate)
+	}
+	if window, ok := header.SameDayWindow(); !ok || window.Name != "3" {
+		t.Errorf("SameDayWindow() = %v, %v, want window 3", window, ok)
+	}
+}
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// standardEntryClassCodes are the Standard Entry Class Codes supported in a NachaBatchHeader
+var standardEntryClassCodes = []string{"PPD", "CCD", "WEB", "TEL", "CTX", "IAT", "COR"}
+
+// isStandardEntryClassCode reports whether the code is a supported Standard Entry Class Code
+func isStandardEntryClassCode(code string) bool {
+	return slices.Contains(standardEntryClassCodes, code)
+}
+
+// validateBatchStandardEntryClass checks the rules a batch header must follow for its Standard Entry Class Code
+func validateBatchStandardEntryClass(v *validator, header *NachaBatchHeader) {
+	switch header.StandardEntryClassCode {
+	case "TEL":
+		if header.ServiceClassCode == "220" {
+			v.add("ServiceClassCode", "TEL batches can only contain debits and cannot use 220")
+		}
+	case "IAT":
+		validateIATBatchHeader(v, header)
+	}
+}
+
+// validateStandardEntryClass checks the rules an entry must follow for the Standard Entry Class Code of its batch
+func validateStandardEntryClass(v *validator, header *NachaBatchHeader, entry *NachaEntry) {
+	switch header.StandardEntryClassCode {
+	case "PPD", "CCD":
+		if len(entry.Addenda) > 1 {
+			v.add("Addenda", header.StandardEntryClassCode+" entries can have at most 1 addenda record")
+		}
+	case "WEB":
+		if len(entry.Addenda) > 1 {
+			v.add("Addenda", "WEB entries can have at most 1 addenda record")
+		}
+		if code := entry.PaymentTypeCode(); entry.IsDebit() && code != "R" && code != "S" {
+			v.add("DiscretionaryData", "WEB debit entries must carry the Payment Type Code R (recurring) or S (single entry)")
+		}
+	case "TEL":
+		if !entry.IsDebit() {
+			v.add("TransactionCode", "TEL entries must be debits")
+		}
+		for _, addenda := range entry.Addenda {
+			if !addenda.isReturn() {
+				v.add("Addenda", "TEL entries cannot have addenda records other than returns")
+				break
+			}
+		}
+		if code := entry.PaymentTypeCode(); code != "" && code != "R" && code != "S" {
+			v.add("DiscretionaryData", "TEL entries must carry the Payment Type Code R (recurring), S (single entry) or blank")
+		}
+	case "CTX":
+		validateCTX(v, entry)
+	case "IAT":
+		validateIATEntry(v, entry)
+	case "COR":
+		if amount, err := entry.AmountCents(); err == nil && amount != 0 {
+			v.add("Amount", "COR entries must have a zero amount")
+		}
+		if len(entry.Addenda) != 1 || !entry.Addenda[0].isNOC() {
+			v.add("Addenda", "COR entries must have exactly one notification of change addenda record (98)")
+		}
+		if !entry.IsReturn() {
+			v.add("TransactionCode", "COR entries must use a return or notification of change transaction code")
+		}
+	}
+}
+
+// AddAddenda adds an addenda record (type 05) to an entry of the batch, following the addenda rules of the
+// Standard Entry Class Code of the batch. TEL entries cannot have addenda records, PPD, CCD and WEB entries can have
+// at most one, and IAT and COR entries must use AddIATAddenda and NewNOCEntry instead.
+func (b *NachaBatch) AddAddenda(entry *NachaEntry) (*NachaAddenda, error) {
+	if !slices.Contains(b.Entries, entry) {
+		return nil, errors.New("entry does not belong to the batch")
+	}
+
+	switch b.Header.StandardEntryClassCode {
+	case "TEL":
+		return nil, errors.New("TEL entries cannot have addenda records")
+	case "IAT":
+		return nil, errors.New("IAT addenda records must be added with AddIATAddenda")
+	case "COR":
+		return nil, errors.New("COR addenda records must be built with NewNOCEntry")
+	case "PPD", "CCD", "WEB":
+		if len(entry.Addenda) > 0 {
+			return nil, errors.New(b.Header.StandardEntryClassCode + " entries can have at most 1 addenda record")
+		}
+	}
+
+	return entry.NewAddenda(), nil
+}
+
+// validateAddendaStandardEntryClass checks that the addenda type is allowed for the Standard Entry Class Code of its batch
+func validateAddendaStandardEntryClass(v *validator, header *NachaBatchHeader, addenda *NachaAddenda) {
+	allowed := []string{"05", "99"}
+	switch header.StandardEntryClassCode {
+	case "IAT":
+		allowed = []string{"10", "11", "12", "13", "14", "15", "16", "17", "18", "99"}
+	case "COR":
+		allowed = []string{"98", "99"}
+	}
+
+	if !slices.Contains(allowed, addenda.AddendaTypeCode) {
+		v.add("AddendaTypeCode", header.StandardEntryClassCode+" addenda records must be one of "+strings.Join(allowed, ", "))
+	}
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAddAddenda(t *testing.T) {
+	tests := []struct {
+		name     string
+		sec      string
+		existing int // Addenda records already on the entry
+		wantErr  string
+	}{
+		{name: "CCD", sec: "CCD"},
+		{name: "CCD second addenda", sec: "CCD", existing: 1, wantErr: "CCD entries can have at most 1 addenda record"},
+		{name: "WEB second addenda", sec: "WEB", existing: 1, wantErr: "WEB entries can have at most 1 addenda record"},
+		{name: "CTX", sec: "CTX", existing: 3},
+		{name: "TEL", sec: "TEL", wantErr: "TEL entries cannot have addenda records"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := addTestBatch(t, newTestFile(t), tt.sec, 225, 27)
+			entry := batch.Entries[0]
+			for range tt.existing {
+				entry.NewAddenda()
+			}
+
+			addenda, err := batch.AddAddenda(entry)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("AddAddenda() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(entry.Addenda) != tt.existing {
+					t.Errorf("AddAddenda() added an addenda record after failing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddAddenda() error = %v", err)
+			}
+			if addenda.AddendaSequenceNumber != fmt.Sprintf("%04d", tt.existing+1) || !entry.HasAddenda() {
+				t.Errorf("AddAddenda() = sequence %q, indicator %q", addenda.AddendaSequenceNumber, entry.AddendaRecordIndicator)
+			}
+		})
+	}
+
+	batch := addTestBatch(t, newTestFile(t), "CCD", 225, 27)
+	if _, err := batch.AddAddenda(&NachaEntry{}); err == nil {
+		t.Errorf("AddAddenda() of an entry outside the batch error = nil")
+	}
+}
+
+func TestSetPaymentTypeCode(t *testing.T) {
+	for _, code := range []string{"R", "S"} {
+		entry := &NachaEntry{}
+		entry.Default()
+		if err := entry.SetPaymentTypeCode(code); err != nil {
+			t.Fatalf("SetPaymentTypeCode(%q) error = %v", code, err)
+		}
+		if entry.DiscretionaryData != code+" " || entry.PaymentTypeCode() != code {
+			t.Errorf("SetPaymentTypeCode(%q) = %q", code, entry.DiscretionaryData)
+		}
+	}
+
+	for _, code := range []string{"", "r", "X", "RS"} {
+		entry := &NachaEntry{}
+		entry.Default()
+		if err := entry.SetPaymentTypeCode(code); err == nil || err.Error() != "PaymentTypeCode must be R or S" {
+			t.Errorf("SetPaymentTypeCode(%q) error = %v", code, err)
+		}
+		if entry.DiscretionaryData != "  " {
+			t.Errorf("SetPaymentTypeCode(%q) changed the DiscretionaryData to %q", code, entry.DiscretionaryData)
+		}
+	}
+}
+
+func TestValidateWEBPaymentTypeCode(t *testing.T) {
+	tests := []struct {
+		name            string
+		transactionCode int
+		data            string // DiscretionaryData of the entry
+		wantErr         bool
+	}{
+		{name: "debit recurring", transactionCode: 27, data: "R "},
+		{name: "debit single entry", transactionCode: 27, data: "S "},
+		{name: "debit blank", transactionCode: 27, data: "  ", wantErr: true},
+		{name: "debit unknown code", transactionCode: 27, data: "X ", wantErr: true},
+		{name: "credit blank", transactionCode: 22, data: "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			batch := addTestBatch(t, file, "WEB", 200, tt.transactionCode)
+			batch.Entries[0].DiscretionaryData = tt.data
+			must(t, file.GenerateFile())
+
+			err := file.Validate()
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			for _, e := range errs {
+				if e.Record == 3 && e.Field == "DiscretionaryData" && strings.Contains(e.Rule, "WEB debit entries must carry the Payment Type Code R") {
+					return
+				}
+			}
+			t.Errorf("Validate() error =\n%v\nwant the WEB Payment Type Code rule", err)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SequenceStore persists the last trace sequence number used for each ODFI, so trace numbers
+// keep increasing across the files generated over time
+type SequenceStore interface {
+	// LastTraceSequence returns the last trace sequence number used for the ODFI, or 0 if none was used yet
+	LastTraceSequence(odfiId string) (int, error)
+	// SaveTraceSequence stores the last trace sequence number used for the ODFI
+	SaveTraceSequence(odfiId string, sequence int) error
+}
+
+// NachaSequencer assigns batch numbers and trace numbers when the file is generated.
+// The sequencer remembers the trace numbers it assigned, so generating the same file again keeps them and only
+// numbers the entries added since. Use a new NachaSequencer for every file.
+type NachaSequencer struct {
+	Store SequenceStore // Optional | Without a Store, trace sequence numbers start from 1 for every file
+
+	assigned map[string]bool // Trace numbers assigned so far
+	last     map[string]int  // Last trace sequence number assigned for each ODFI, used without a Store
+}
+
+// Assign numbers the batches of the file sequentially from 1, and gives every entry a trace number made of its
+// batch ODFIIdentification and a sequence number that increases across the whole file for each ODFI.
+// Entries that still carry a trace number assigned by a previous call keep it, so Assign can be run again
+// after the file changes without using up new sequence numbers.
+// When a Store is set, the sequence continues from the last stored value, and the last used value is saved back.
+func (s *NachaSequencer) Assign(f *NachaFile) error {
+	if len(f.Batches) > 9999999 {
+		return errors.New("file cannot have more than 9999999 batches")
+	}
+	if s.assigned == nil {
+		s.assigned = make(map[string]bool)
+		s.last = make(map[string]int)
+	}
+
+	sequences := make(map[string]int)
+	kept := make(map[string]bool)
+	for i, batch := range f.Batches {
+		if err := batch.Header.SetBatchNumber(i + 1); err != nil {
+			return err
+		}
+
+		odfiId := batch.Header.ODFIIdentification
+		if _, ok := sequences[odfiId]; !ok {
+			last := s.last[odfiId]
+			if s.Store != nil {
+				var err error
+				if last, err = s.Store.LastTraceSequence(odfiId); err != nil {
+					return err
+				}
+			}
+			sequences[odfiId] = last
+		}
+
+		for _, entry := range batch.Entries {
+			if s.assigned[entry.TraceNumber] && entry.TraceNumber[:8] == odfiId && !kept[entry.TraceNumber] {
+				kept[entry.TraceNumber] = true
+				continue
+			}
+
+			sequences[odfiId]++
+			if err := entry.SetTraceNumber(odfiId, sequences[odfiId]); err != nil {
+				return fmt.Errorf("batch %d: %w", i+1, err)
+			}
+			s.assigned[entry.TraceNumber] = true
+		}
+	}
+
+	for odfiId, sequence := range sequences {
+		s.last[odfiId] = sequence
+	}
+	if s.Store != nil {
+		for odfiId, sequence := range sequences {
+			if err := s.Store.SaveTraceSequence(odfiId, sequence); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// lastTraceSequences records the highest trace sequence number used by the entries for each ODFI in sequences
+func lastTraceSequences(sequences map[string]int, entries []*NachaEntry) {
+	for _, entry := range entries {
+		if sequence, err := entry.TraceSequence(); err == nil {
+			odfiId := entry.TraceNumber[:8]
+			sequences[odfiId] = max(sequences[odfiId], sequence)
+		}
+	}
+}
+
+// MemorySequenceStore is a SequenceStore that keeps the last trace sequence numbers in memory
+type MemorySequenceStore struct {
+	mu        sync.Mutex
+	sequences map[string]int
+}
+
+// LastTraceSequence returns the last trace sequence number used for the ODFI, or 0 if none was used yet
+func (m *MemorySequenceStore) LastTraceSequence(odfiId string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sequences[odfiId], nil
+}
+
+// SaveTraceSequence stores the last trace sequence number used for the ODFI
+func (m *MemorySequenceStore) SaveTraceSequence(odfiId string, sequence int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sequences == nil {
+		m.sequences = make(map[string]int)
+	}
+	m.sequences[odfiId] = sequence
+	return nil
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+// traceNumbers returns the trace numbers of every entry of the file
+func traceNumbers(f *NachaFile) []string {
+	var traces []string
+	for _, batch := range f.Batches {
+		for _, entry := range batch.Entries {
+			traces = append(traces, entry.TraceNumber)
+		}
+	}
+
+	return traces
+}
+
+func TestSequencerAssign(t *testing.T) {
+	store := &MemorySequenceStore{}
+	must(t, store.SaveTraceSequence(testODFI, 100))
+
+	file := newTestFile(t)
+	addTestBatch(t, file, "PPD", 225, 27, 27)
+	addTestBatch(t, file, "PPD", 220, 22)
+	file.Sequencer = &NachaSequencer{Store: store}
+	must(t, file.GenerateFile())
+
+	want := []string{"011000010000101", "011000010000102", "011000010000103"}
+	if got := traceNumbers(file); !slices.Equal(got, want) {
+		t.Fatalf("trace numbers = %v, want %v", got, want)
+	}
+	if number := file.Batches[1].Header.BatchNumber; number != "0000002" {
+		t.Errorf("BatchNumber = %q, want \"0000002\"", number)
+	}
+	if last, _ := store.LastTraceSequence(testODFI); last != 103 {
+		t.Errorf("stored sequence = %d, want 103", last)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestSequencerAssignIsIdempotent(t *testing.T) {
+	tests := []struct {
+		name   string
+		store  SequenceStore
+		offset *NachaOffset
+	}{
+		{name: "without store"},
+		{name: "with store", store: &MemorySequenceStore{}},
+		{
+			name:  "with offset",
+			store: &MemorySequenceStore{},
+			offset: &NachaOffset{
+				RoutingNumber:    "011000015",
+				DFIAccountNumber: "123456789",
+				AccountType:      AccountTypeChecking,
+				Name:             "ABC Company",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			batch := addTestBatch(t, file, "PPD", 225, 27, 27)
+			file.Offset = tt.offset
+			file.Sequencer = &NachaSequencer{Store: tt.store}
+
+			must(t, file.GenerateFile())
+			first := traceNumbers(file)
+			must(t, file.GenerateFile())
+			if got := traceNumbers(file); !slices.Equal(got, first) {
+				t.Fatalf("trace numbers after a second GenerateFile = %v, want %v", got, first)
+			}
+
+			entry := batch.AddEntry()
+			*entry = *batch.Entries[0]
+			entry.TraceNumber = ""
+			must(t, file.GenerateFile())
+
+			traces := traceNumbers(file)
+			if want := len(first) + 1; len(traces) != want {
+				t.Fatalf("file has %d entries, want %d", len(traces), want)
+			}
+			for i, trace := range first {
+				if !slices.Contains(traces, trace) {
+					t.Errorf("trace number %d %q was not kept", i, trace)
+				}
+			}
+			if err := file.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+			if tt.store != nil {
+				if last, _ := tt.store.LastTraceSequence(testODFI); last != len(traces) {
+					t.Errorf("stored sequence = %d, want %d", last, len(traces))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package types
+
+import "errors"
+
+// SplitMixedBatches splits every mixed (200) batch into a credit only (220) batch and a debit only (225) batch
+// with the same header data. Mixed batches holding only credits or only debits keep their entries and get the
+// matching ServiceClassCode, and mixed batches without entries are kept as they are. Batch numbers are then assigned from 1 in file order, and the batch and file controls
+// are recomputed. Balanced files cannot be split, as the offset entries would mix the batches again.
+func (f *NachaFile) SplitMixedBatches() error {
+	if f.Offset != nil {
+		return errors.New("batches of a file with an Offset cannot be split")
+	}
+
+	var batches []*NachaBatch
+	for _, batch := range f.Batches {
+		if batch.Header.ServiceClassCode != "200" || len(batch.Entries) == 0 {
+			batches = append(batches, batch)
+			continue
+		}
+		if batch.Offset != nil {
+			return errors.New("batches with an Offset cannot be split")
+		}
+
+		credits := &NachaBatch{Header: batch.Header, Control: batch.Control}
+		debits := &NachaBatch{Header: batch.Header, Control: batch.Control}
+		credits.Header.ServiceClassCode = "220"
+		debits.Header.ServiceClassCode = "225"
+
+		for _, entry := range batch.Entries {
+			switch {
+			case entry.IsCredit():
+				credits.Entries = append(credits.Entries, entry)
+			case entry.IsDebit():
+				debits.Entries = append(debits.Entries, entry)
+			default:
+				return errors.New("TransactionCode " + entry.TransactionCode + " is neither a debit nor a credit")
+			}
+		}
+
+		for _, split := range []*NachaBatch{credits, debits} {
+			if len(split.Entries) > 0 {
+				batches = append(batches, split)
+			}
+		}
+	}
+
+	for i, batch := range batches {
+		if err := batch.Header.SetBatchNumber(i + 1); err != nil {
+			return err
+		}
+		batch.GenerateBatchControl()
+	}
+
+	f.Batches = batches
+	f.GenerateFileControl()
+	return nil
+}
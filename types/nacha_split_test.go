@@ -0,0 +1,94 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMixedBatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		batches [][]int // Transaction codes of the entries of every batch, all added as mixed (200) batches
+		want    []string
+	}{
+		{name: "mixed", batches: [][]int{{22, 27, 32}}, want: []string{"220 22 32", "225 27"}},
+		{name: "credits only", batches: [][]int{{22, 32}}, want: []string{"220 22 32"}},
+		{name: "debits only", batches: [][]int{{27, 37}}, want: []string{"225 27 37"}},
+		{name: "no entries", batches: [][]int{{}}, want: []string{"200"}},
+		{name: "several batches", batches: [][]int{{27, 22}, {}, {37}}, want: []string{"220 22", "225 27", "200", "225 37"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			for _, codes := range tt.batches {
+				addTestBatch(t, file, "PPD", 200, codes...)
+			}
+			must(t, file.GenerateFile())
+
+			if err := file.SplitMixedBatches(); err != nil {
+				t.Fatalf("SplitMixedBatches() error = %v", err)
+			}
+
+			var got []string
+			for i, batch := range file.Batches {
+				summary := batch.Header.ServiceClassCode
+				for _, entry := range batch.Entries {
+					summary += " " + entry.TransactionCode
+				}
+				got = append(got, summary)
+
+				if number, err := batch.Header.BatchNumberValue(); err != nil || number != i+1 || batch.Control.BatchNumber != batch.Header.BatchNumber {
+					t.Errorf("batch %d numbered %q, control %q", i+1, batch.Header.BatchNumber, batch.Control.BatchNumber)
+				}
+				if batch.Control.ServiceClassCode != batch.Header.ServiceClassCode {
+					t.Errorf("batch %d control ServiceClassCode = %q, want %q", i+1, batch.Control.ServiceClassCode, batch.Header.ServiceClassCode)
+				}
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("SplitMixedBatches() = %v, want %v", got, tt.want)
+			}
+			if count, err := file.Control.BatchCountValue(); err != nil || count != len(tt.want) {
+				t.Errorf("BatchCount = %q, want %d", file.Control.BatchCount, len(tt.want))
+			}
+			if err := file.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSplitMixedBatchesErrors(t *testing.T) {
+	offset := &NachaOffset{
+		RoutingNumber:    "011000015",
+		DFIAccountNumber: "123456789",
+		AccountType:      AccountTypeChecking,
+		Name:             "ABC Company",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(f *NachaFile)
+		wantErr string
+	}{
+		{name: "file offset", modify: func(f *NachaFile) { f.Offset = offset }, wantErr: "batches of a file with an Offset cannot be split"},
+		{name: "batch offset", modify: func(f *NachaFile) { f.Batches[0].Offset = offset }, wantErr: "batches with an Offset cannot be split"},
+		{name: "unknown transaction code", modify: func(f *NachaFile) { f.Batches[0].Entries[0].TransactionCode = "25" }, wantErr: "TransactionCode 25 is neither a debit nor a credit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestFile(t)
+			addTestBatch(t, file, "PPD", 200, 22, 27)
+			tt.modify(file)
+			before := file.String()
+
+			if err := file.SplitMixedBatches(); err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("SplitMixedBatches() error = %v, want %q", err, tt.wantErr)
+			}
+			if file.String() != before || len(file.Batches) != 1 {
+				t.Errorf("SplitMixedBatches() changed the file after failing")
+			}
+		})
+	}
+}
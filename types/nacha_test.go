@@ -0,0 +1,79 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+// testODFI is the ODFI identification used by the test batches
+const testODFI = "01100001"
+
+// newTestFile returns a file with a valid header and no batches
+func newTestFile(t *testing.T) *NachaFile {
+	t.Helper()
+
+	file := &NachaFile{}
+	file.Header.Default()
+	file.Control.Default()
+	must(t, file.Header.SetImmediateDestination("021000021"))
+	must(t, file.Header.SetImmediateDestinationName("Destination Bank"))
+	must(t, file.Header.SetImmediateOrigin("011000015"))
+	must(t, file.Header.SetImmediateOriginName("Origin Bank"))
+	return file
+}
+
+// addTestBatch adds a batch of the Standard Entry Class Code and Service Class Code to the file,
+// with one entry of 100.25 for every transaction code. Entries are numbered after the entries of the
+// previous batches, so trace numbers stay unique within the file.
+func addTestBatch(t *testing.T, file *NachaFile, sec string, serviceClassCode int, transactionCodes ...int) *NachaBatch {
+	t.Helper()
+
+	sequence := 0
+	for _, batch := range file.Batches {
+		sequence += len(batch.Entries)
+	}
+
+	batch := file.NewBatch()
+	must(t, batch.Header.SetServiceClassCode(serviceClassCode))
+	must(t, batch.Header.SetCompanyName("ABC Company"))
+	must(t, batch.Header.SetCompanyIdentification("1122334455"))
+	must(t, batch.Header.SetStandardEntryClassCode(sec))
+	must(t, batch.Header.SetCompanyEntryDescription("Payroll"))
+	batch.Header.SetEffectiveEntryDate(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	must(t, batch.Header.SetODFIIdentification(testODFI))
+	must(t, batch.Header.SetBatchNumber(len(file.Batches)))
+
+	for _, code := range transactionCodes {
+		sequence++
+
+		entry := batch.AddEntry()
+		must(t, entry.SetTransactionCode(code))
+		must(t, entry.SetReceivingDFI("021000021"))
+		must(t, entry.SetDFIAccountNumber("29079117"))
+		if !entry.carriesZeroAmount() {
+			must(t, entry.SetAmount(Dollars(100, 25)))
+		} else {
+			must(t, entry.SetAmount(0))
+		}
+		must(t, entry.SetIndividualIDNumber("392344"))
+		if sec == "CTX" {
+			must(t, entry.SetReceivingCompanyName("BBC Company"))
+		} else {
+			must(t, entry.SetIndividualName("BBC Company"))
+		}
+		if sec == "WEB" || sec == "TEL" {
+			must(t, entry.SetPaymentTypeCode("S"))
+		}
+		must(t, entry.SetTraceNumber(testODFI, sequence))
+	}
+
+	return batch
+}
+
+// must fails the test when err is not nil
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
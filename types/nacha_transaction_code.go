@@ -0,0 +1,145 @@
+package types
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// TransactionCodeInfo describes a NACHA transaction code
+type TransactionCodeInfo struct {
+	AccountType  AccountType
+	Debit        bool // Debits the receiver's account, credits it otherwise
+	Prenote      bool // Prenotification of a future entry, carries a zero amount
+	ZeroDollar   bool // Zero dollar entry carrying remittance data in its addenda
+	Return       bool // Automated return or notification of change
+	ReversalOnly bool // Only allowed to reverse an erroneous entry
+	Description  string
+}
+
+// transactionCodes is the table of every transaction code
+var transactionCodes = map[string]TransactionCodeInfo{
+	"21": {AccountType: AccountTypeChecking, Return: true, Description: "Checking Automated Return or Notification of Change (Credit)"},
+	"22": {AccountType: AccountTypeChecking, Description: "Checking Credit"},
+	"23": {AccountType: AccountTypeChecking, Prenote: true, Description: "Checking Prenotification (Credit)"},
+	"24": {AccountType: AccountTypeChecking, ZeroDollar: true, Description: "Checking Zero Dollar with Remittance Data (Credit)"},
+	"26": {AccountType: AccountTypeChecking, Debit: true, Return: true, Description: "Checking Automated Return or Notification of Change (Debit)"},
+	"27": {AccountType: AccountTypeChecking, Debit: true, Description: "Checking Debit"},
+	"28": {AccountType: AccountTypeChecking, Debit: true, Prenote: true, Description: "Checking Prenotification (Debit)"},
+	"29": {AccountType: AccountTypeChecking, Debit: true, ZeroDollar: true, Description: "Checking Zero Dollar with Remittance Data (Debit)"},
+
+	"31": {AccountType: AccountTypeSavings, Return: true, Description: "Savings Automated Return or Notification of Change (Credit)"},
+	"32": {AccountType: AccountTypeSavings, Description: "Savings Credit"},
+	"33": {AccountType: AccountTypeSavings, Prenote: true, Description: "Savings Prenotification (Credit)"},
+	"34": {AccountType: AccountTypeSavings, ZeroDollar: true, Description: "Savings Zero Dollar with Remittance Data (Credit)"},
+	"36": {AccountType: AccountTypeSavings, Debit: true, Return: true, Description: "Savings Automated Return or Notification of Change (Debit)"},
+	"37": {AccountType: AccountTypeSavings, Debit: true, Description: "Savings Debit"},
+	"38": {AccountType: AccountTypeSavings, Debit: true, Prenote: true, Description: "Savings Prenotification (Debit)"},
+	"39": {AccountType: AccountTypeSavings, Debit: true, ZeroDollar: true, Description: "Savings Zero Dollar with Remittance Data (Debit)"},
+
+	"41": {AccountType: AccountTypeGeneralLedger, Return: true, Description: "General Ledger Automated Return or Notification of Change (Credit)"},
+	"42": {AccountType: AccountTypeGeneralLedger, Description: "General Ledger Credit"},
+	"43": {AccountType: AccountTypeGeneralLedger, Prenote: true, Description: "General Ledger Prenotification (Credit)"},
+	"44": {AccountType: AccountTypeGeneralLedger, ZeroDollar: true, Description: "General Ledger Zero Dollar with Remittance Data (Credit)"},
+	"46": {AccountType: AccountTypeGeneralLedger, Debit: true, Return: true, Description: "General Ledger Automated Return or Notification of Change (Debit)"},
+	"47": {AccountType: AccountTypeGeneralLedger, Debit: true, Description: "General Ledger Debit"},
+	"48": {AccountType: AccountTypeGeneralLedger, Debit: true, Prenote: true, Description: "General Ledger Prenotification (Debit)"},
+
+	"51": {AccountType: AccountTypeLoan, Return: true, Description: "Loan Automated Return or Notification of Change (Credit)"},
+	"52": {AccountType: AccountTypeLoan, Description: "Loan Credit"},
+	"53": {AccountType: AccountTypeLoan, Prenote: true, Description: "Loan Prenotification (Credit)"},
+	"54": {AccountType: AccountTypeLoan, ZeroDollar: true, Description: "Loan Zero Dollar with Remittance Data (Credit)"},
+	"55": {AccountType: AccountTypeLoan, Debit: true, ReversalOnly: true, Description: "Loan Debit (Reversals Only)"},
+	"56": {AccountType: AccountTypeLoan, Debit: true, Return: true, Description: "Loan Automated Return or Notification of Change (Debit)"},
+}
+
+// LookupTransactionCode returns the description of a transaction code
+func LookupTransactionCode(code int) (TransactionCodeInfo, bool) {
+	info, ok := transactionCodes[strconv.Itoa(code)]
+	return info, ok
+}
+
+// transactionCodeList returns every transaction code in ascending order
+func transactionCodeList() []string {
+	return slices.Sorted(maps.Keys(transactionCodes))
+}
+
+// findTransactionCode returns the transaction code matching the account type, direction and kind of info
+func findTransactionCode(info TransactionCodeInfo) (string, bool) {
+	info.Description = ""
+	for _, code := range transactionCodeList() {
+		candidate := transactionCodes[code]
+		candidate.Description = ""
+		if candidate == info {
+			return code, true
+		}
+	}
+
+	return "", false
+}
+
+// liveTransactionCode returns the transaction code of a live debit or credit to the account type.
+// Codes that are only allowed for reversals are left out.
+func liveTransactionCode(accountType AccountType, debit bool) (string, bool) {
+	return findTransactionCode(TransactionCodeInfo{AccountType: accountType, Debit: debit})
+}
+
+// prenoteTransactionCode returns the prenote transaction code for the receiver of an entry with the given code
+func prenoteTransactionCode(code string) (string, bool) {
+	info, ok := transactionCodes[code]
+	if !ok || info.Return {
+		return "", false
+	}
+
+	return findTransactionCode(TransactionCodeInfo{AccountType: info.AccountType, Debit: info.Debit, Prenote: true})
+}
+
+// returnTransactionCode returns the transaction code of the return or notification of change of an entry
+// with the given code
+func returnTransactionCode(code string) (string, bool) {
+	info, ok := transactionCodes[code]
+	if !ok || info.Return {
+		return "", false
+	}
+
+	return findTransactionCode(TransactionCodeInfo{AccountType: info.AccountType, Debit: info.Debit, Return: true})
+}
+
+// reversalTransactionCode returns the transaction code that reverses a live entry with the given code
+func reversalTransactionCode(code string) (string, bool) {
+	info, ok := transactionCodes[code]
+	if !ok || info.Prenote || info.ZeroDollar || info.Return {
+		return "", false
+	}
+
+	if code, ok := liveTransactionCode(info.AccountType, !info.Debit); ok {
+		return code, true
+	}
+	return findTransactionCode(TransactionCodeInfo{AccountType: info.AccountType, Debit: !info.Debit, ReversalOnly: true})
+}
+
+// carriesZeroAmount reports whether the TransactionCode requires a zero amount
+func (e *NachaEntry) carriesZeroAmount() bool {
+	info, ok := transactionCodes[e.TransactionCode]
+	return ok && (info.Prenote || info.ZeroDollar)
+}
+
+// IsZeroDollar reports whether the TransactionCode is a zero dollar entry with remittance data
+func (e *NachaEntry) IsZeroDollar() bool {
+	return transactionCodes[e.TransactionCode].ZeroDollar
+}
+
+// IsReturn reports whether the TransactionCode is an automated return or notification of change
+func (e *NachaEntry) IsReturn() bool {
+	return transactionCodes[e.TransactionCode].Return
+}
+
+// validateZeroDollar checks that zero dollar entries carry a zero amount and their remittance data in an addenda
+func validateZeroDollar(v *validator, entry *NachaEntry) {
+	if amount, err := entry.AmountCents(); err == nil && amount != 0 {
+		v.add("Amount", "must be zero for zero dollar entries")
+	}
+	if len(entry.Addenda) == 0 {
+		v.add("Addenda", "zero dollar entries must carry their remittance data in an addenda record")
+	}
+}
@@ -0,0 +1,80 @@
+package types
+
+import "testing"
+
+func TestTransactionCodeConversions(t *testing.T) {
+	tests := []struct {
+		code     string
+		prenote  string
+		reversal string
+		ret      string
+	}{
+		{code: "22", prenote: "23", reversal: "27", ret: "21"},
+		{code: "27", prenote: "28", reversal: "22", ret: "26"},
+		{code: "24", prenote: "23", ret: "21"},
+		{code: "38", prenote: "38", ret: "36"},
+		{code: "42", prenote: "43", reversal: "47", ret: "41"},
+		{code: "47", prenote: "48", reversal: "42", ret: "46"},
+		{code: "52", prenote: "53", reversal: "55", ret: "51"},
+		{code: "55", reversal: "52", ret: "56"},
+		{code: "21"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got, _ := prenoteTransactionCode(tt.code); got != tt.prenote {
+				t.Errorf("prenoteTransactionCode(%s) = %q, want %q", tt.code, got, tt.prenote)
+			}
+			if got, _ := reversalTransactionCode(tt.code); got != tt.reversal {
+				t.Errorf("reversalTransactionCode(%s) = %q, want %q", tt.code, got, tt.reversal)
+			}
+			if got, _ := returnTransactionCode(tt.code); got != tt.ret {
+				t.Errorf("returnTransactionCode(%s) = %q, want %q", tt.code, got, tt.ret)
+			}
+		})
+	}
+}
+
+func TestLiveTransactionCode(t *testing.T) {
+	tests := []struct {
+		accountType AccountType
+		debit       bool
+		want        string
+	}{
+		{accountType: AccountTypeChecking, want: "22"},
+		{accountType: AccountTypeChecking, debit: true, want: "27"},
+		{accountType: AccountTypeSavings, debit: true, want: "37"},
+		{accountType: AccountTypeGeneralLedger, debit: true, want: "47"},
+		{accountType: AccountTypeLoan, want: "52"},
+		{accountType: AccountTypeLoan, debit: true},
+	}
+
+	for _, tt := range tests {
+		got, ok := liveTransactionCode(tt.accountType, tt.debit)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("liveTransactionCode(%s, %v) = %q, %v, want %q", tt.accountType, tt.debit, got, ok, tt.want)
+		}
+	}
+}
+
+func TestGenerateOffsetLoanAccount(t *testing.T) {
+	offset := &NachaOffset{
+		RoutingNumber:    "011000015",
+		DFIAccountNumber: "123456789",
+		AccountType:      AccountTypeLoan,
+		Name:             "ABC Company",
+	}
+
+	debits := addTestBatch(t, newTestFile(t), "PPD", 225, 27)
+	if err := debits.GenerateOffset(offset); err != nil || debits.Entries[1].TransactionCode != "52" {
+		t.Errorf("GenerateOffset() of a debit batch = %v, want a 52 loan credit offset", err)
+	}
+
+	credits := addTestBatch(t, newTestFile(t), "PPD", 220, 22)
+	if err := credits.GenerateOffset(offset); err == nil || err.Error() != "offset entries cannot debit loan accounts" {
+		t.Errorf("GenerateOffset() of a credit batch error = %v, want \"offset entries cannot debit loan accounts\"", err)
+	}
+	if len(credits.Entries) != 1 {
+		t.Errorf("GenerateOffset() added an offset entry after failing")
+	}
+}
@@ -0,0 +1,394 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rashintha/nacha/routing"
+	"github.com/rashintha/nacha/util"
+)
+
+// ValidationError describes a single rule broken by a record of a NachaFile
+type ValidationError struct {
+	Record     int    // Position of the record in the generated file, starting at 1
+	RecordType string // Name of the record type (NachaFileHeader, NachaEntry etc.)
+	Field      string // Name of the field that broke the rule
+	Rule       string // Description of the rule that was broken
+}
+
+// Error returns the ValidationError as a string
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("record %d (%s) %s: %s", e.Record, e.RecordType, e.Field, e.Rule)
+}
+
+// ValidationErrors is the list of every rule broken by a NachaFile
+type ValidationErrors []*ValidationError
+
+// Error returns all the ValidationErrors as a single string, one error per line
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the individual ValidationErrors so they can be inspected with errors.As
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// Validate checks every record of the file and the totals that tie the records together.
+// It returns nil when the file is valid, or ValidationErrors listing every rule broken.
+func (f *NachaFile) Validate() error {
+	v := &validator{}
+
+	v.next("NachaFileHeader")
+	f.Header.validate(v)
+
+	traceNumbers := make(map[string]int)
+	entryAddendaCount := 0
+	entryHash := int64(0)
+	totalDebits := Amount(0)
+	totalCredits := Amount(0)
+
+	for _, batch := range f.Batches {
+		v.next("NachaBatchHeader")
+		batch.Header.validate(v)
+		validateBatchStandardEntryClass(v, &batch.Header)
+		if batch.Header.IsSameDay() {
+			validateSameDay(v, &batch.Header)
+		}
+
+		for _, entry := range batch.Entries {
+			v.next("NachaEntry")
+			entry.validate(v, batch.Header.StandardEntryClassCode)
+			validateStandardEntryClass(v, &batch.Header, entry)
+			if batch.Header.IsSameDay() {
+				validateSameDayEntry(v, entry)
+			}
+			if entry.IsPrenote() {
+				validatePrenote(v, entry)
+			}
+			if entry.IsZeroDollar() {
+				validateZeroDollar(v, entry)
+			}
+			if batch.Header.ServiceClassCode == "220" && entry.IsDebit() {
+				v.add("TransactionCode", "debit entries are not allowed in credit only (220) batches")
+			}
+			if batch.Header.ServiceClassCode == "225" && entry.IsCredit() {
+				v.add("TransactionCode", "credit entries are not allowed in debit only (225) batches")
+			}
+
+			if entry.TraceNumber[:min(8, len(entry.TraceNumber))] != batch.Header.ODFIIdentification {
+				v.add("TraceNumber", "must start with the batch ODFIIdentification")
+			}
+			if record, ok := traceNumbers[entry.TraceNumber]; ok {
+				v.add("TraceNumber", fmt.Sprintf("duplicates the trace number of record %d", record))
+			} else {
+				traceNumbers[entry.TraceNumber] = v.record
+			}
+
+			for _, addenda := range entry.Addenda {
+				v.next("NachaAddenda")
+				addenda.validate(v)
+				validateAddendaStandardEntryClass(v, &batch.Header, addenda)
+
+				if len(entry.TraceNumber) == 15 && addenda.EntryDetailSequenceNumber != entry.TraceNumber[8:] {
+					v.add("EntryDetailSequenceNumber", "must match the last 7 digits of the entry TraceNumber")
+				}
+			}
+		}
+
+		v.next("NachaBatchControl")
+		batch.Control.validate(v)
+
+		count, hash, debits, credits := batch.totals()
+		v.equal("ServiceClassCode", batch.Control.ServiceClassCode, batch.Header.ServiceClassCode, "the batch header")
+		v.equal("EntryAddendaCount", batch.Control.EntryAddendaCount, util.ToFixedWidthZeroString(strconv.Itoa(count), 6), "the entries and addenda of the batch")
+		v.equal("EntryHash", batch.Control.EntryHash, formatEntryHash(hash), "the entries of the batch")
+		v.equal("TotalDebits", batch.Control.TotalDebits, debits.format(12), "the debit entries of the batch")
+		v.equal("TotalCredits", batch.Control.TotalCredits, credits.format(12), "the credit entries of the batch")
+		v.equal("CompanyIdentification", batch.Control.CompanyIdentification, batch.Header.CompanyIdentification, "the batch header")
+		v.equal("ODFIIdentification", batch.Control.ODFIIdentification, batch.Header.ODFIIdentification, "the batch header")
+		v.equal("BatchNumber", batch.Control.BatchNumber, batch.Header.BatchNumber, "the batch header")
+
+		batchCount, _ := batch.Control.EntryAddendaCountValue()
+		batchHash, _ := batch.Control.EntryHashValue()
+		batchDebits, _ := batch.Control.TotalDebitsCents()
+		batchCredits, _ := batch.Control.TotalCreditsCents()
+
+		entryAddendaCount += batchCount
+		entryHash += batchHash
+		totalDebits += batchDebits
+		totalCredits += batchCredits
+	}
+
+	v.next("NachaFileControl")
+	f.Control.validate(v)
+
+	recordCount := v.record + len(f.BlockFillers)
+	v.equal("BatchCount", f.Control.BatchCount, util.ToFixedWidthZeroString(strconv.Itoa(len(f.Batches)), 6), "the number of batches")
+	v.equal("BlockCount", f.Control.BlockCount, util.ToFixedWidthZeroString(strconv.Itoa((recordCount+9)/10), 6), "the number of blocks")
+	v.equal("EntryAddendaCount", f.Control.EntryAddendaCount, util.ToFixedWidthZeroString(strconv.Itoa(entryAddendaCount), 8), "the batch controls")
+	v.equal("EntryHash", f.Control.EntryHash, formatEntryHash(entryHash), "the batch controls")
+	v.equal("TotalDebits", f.Control.TotalDebits, totalDebits.format(12), "the batch controls")
+	v.equal("TotalCredits", f.Control.TotalCredits, totalCredits.format(12), "the batch controls")
+
+	if recordCount%10 != 0 {
+		v.add("BlockFillers", "the file must contain a multiple of 10 records")
+	}
+
+	for _, filler := range f.BlockFillers {
+		v.next("NachaBlockFiller")
+		if !IsBlockFiller(filler.Reserved) {
+			v.add("Reserved", "must be 94 characters of 9s")
+		}
+	}
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// validate checks the fields of the NachaFileHeader
+func (h *NachaFileHeader) validate(v *validator) {
+	v.fixed("Type", h.Type, "1")
+	v.numeric("PriorityCode", h.PriorityCode, 2)
+	v.required("ImmediateDestination", h.ImmediateDestination, 10)
+	v.numericOrBlank("ImmediateDestination", strings.TrimLeft(h.ImmediateDestination, " "))
+	v.required("ImmediateOrigin", h.ImmediateOrigin, 10)
+	v.date("FileCreationDate", h.FileCreationDate)
+	if strings.TrimSpace(h.FileCreationTime) != "" {
+		v.time("FileCreationTime", h.FileCreationTime)
+	}
+	v.alphanumeric("FileIDModifier", h.FileIDModifier, 1)
+	v.fixed("RecordSize", h.RecordSize, "094")
+	v.fixed("BlockingFactor", h.BlockingFactor, "10")
+	v.fixed("FormatCode", h.FormatCode, "1")
+	v.width("ImmediateDestinationName", h.ImmediateDestinationName, 23)
+	v.width("ImmediateOriginName", h.ImmediateOriginName, 23)
+	v.width("ReferenceCode", h.ReferenceCode, 8)
+}
+
+// validate checks the fields of the NachaBatchHeader
+func (h *NachaBatchHeader) validate(v *validator) {
+	v.fixed("Type", h.Type, "5")
+	v.oneOf("ServiceClassCode", h.ServiceClassCode, "200", "220", "225")
+	if h.StandardEntryClassCode == "IAT" {
+		v.width("CompanyName", h.CompanyName, 16)
+	} else {
+		v.required("CompanyName", h.CompanyName, 16)
+	}
+	v.width("CompanyDiscretionaryData", h.CompanyDiscretionaryData, 20)
+	v.required("CompanyIdentification", h.CompanyIdentification, 10)
+	v.oneOf("StandardEntryClassCode", h.StandardEntryClassCode, standardEntryClassCodes...)
+	v.required("CompanyEntryDescription", h.CompanyEntryDescription, 10)
+	v.width("CompanyDescriptiveDate", h.CompanyDescriptiveDate, 6)
+	v.date("EffectiveEntryDate", h.EffectiveEntryDate)
+	if v.width("SettlementDateJulian", h.SettlementDateJulian, 3) && strings.TrimSpace(h.SettlementDateJulian) != "" {
+		if day, err := strconv.Atoi(h.SettlementDateJulian); err != nil || day < 1 || day > 366 {
+			v.add("SettlementDateJulian", "must be blank or a day of the year from 001 to 366")
+		}
+	}
+	v.alphanumeric("OriginatorStatusCode", h.OriginatorStatusCode, 1)
+	v.numeric("ODFIIdentification", h.ODFIIdentification, 8)
+	v.numeric("BatchNumber", h.BatchNumber, 7)
+}
+
+// validate checks the fields of the NachaEntry in a batch of the given Standard Entry Class Code
+func (e *NachaEntry) validate(v *validator, standardEntryClassCode string) {
+	v.fixed("Type", e.Type, "6")
+	v.oneOf("TransactionCode", e.TransactionCode, transactionCodeList()...)
+	v.numeric("ReceivingDFIIdentification", e.ReceivingDFIIdentification, 8)
+	v.numeric("CheckDigit", e.CheckDigit, 1)
+	if isDigits(e.ReceivingDFIIdentification) && isDigits(e.CheckDigit) {
+		if digit, err := routing.CheckDigit(e.ReceivingDFIIdentification); err == nil && strconv.Itoa(digit) != e.CheckDigit {
+			v.add("CheckDigit", "must be "+strconv.Itoa(digit)+" to match the ABA checksum of ReceivingDFIIdentification")
+		}
+	}
+	v.required("DFIAccountNumber", e.DFIAccountNumber, 17)
+	v.numeric("Amount", e.Amount, 10)
+	v.width("IndividualIDNumber", e.IndividualIDNumber, 15)
+	if standardEntryClassCode == "IAT" {
+		v.width("IndividualName", e.IndividualName, 22)
+	} else {
+		v.required("IndividualName", e.IndividualName, 22)
+	}
+	v.width("DiscretionaryData", e.DiscretionaryData, 2)
+	v.oneOf("AddendaRecordIndicator", e.AddendaRecordIndicator, "0", "1")
+	v.numeric("TraceNumber", e.TraceNumber, 15)
+
+	if (e.AddendaRecordIndicator == "1") != (len(e.Addenda) > 0) {
+		v.add("AddendaRecordIndicator", "must be 1 when the entry has addenda records and 0 otherwise")
+	}
+}
+
+// validate checks the fields of the NachaAddenda
+func (a *NachaAddenda) validate(v *validator) {
+	v.fixed("Type", a.Type, "7")
+	v.numeric("AddendaTypeCode", a.AddendaTypeCode, 2)
+	v.width("PaymentRelatedInformation", a.PaymentRelatedInformation, 80)
+	if a.hasAddendaSequenceNumber() {
+		v.numeric("AddendaSequenceNumber", a.AddendaSequenceNumber, 4)
+	} else {
+		v.width("AddendaSequenceNumber", a.AddendaSequenceNumber, 4)
+	}
+	v.numeric("EntryDetailSequenceNumber", a.EntryDetailSequenceNumber, 7)
+
+	if a.isReturn() {
+		validateReturn(v, a)
+	}
+	if a.isNOC() {
+		validateNOC(v, a)
+	}
+}
+
+// validate checks the fields of the NachaBatchControl
+func (b *NachaBatchControl) validate(v *validator) {
+	v.fixed("Type", b.Type, "8")
+	v.numeric("EntryAddendaCount", b.EntryAddendaCount, 6)
+	v.numeric("EntryHash", b.EntryHash, 10)
+	v.numeric("TotalDebits", b.TotalDebits, 12)
+	v.numeric("TotalCredits", b.TotalCredits, 12)
+	v.width("MessageAuthenticationCode", b.MessageAuthenticationCode, 19)
+	v.width("Reserved", b.Reserved, 6)
+}
+
+// validate checks the fields of the NachaFileControl
+func (f *NachaFileControl) validate(v *validator) {
+	v.fixed("Type", f.Type, "9")
+	v.numeric("BatchCount", f.BatchCount, 6)
+	v.numeric("BlockCount", f.BlockCount, 6)
+	v.numeric("EntryAddendaCount", f.EntryAddendaCount, 8)
+	v.numeric("EntryHash", f.EntryHash, 10)
+	v.numeric("TotalDebits", f.TotalDebits, 12)
+	v.numeric("TotalCredits", f.TotalCredits, 12)
+	v.width("Reserved", f.Reserved, 39)
+}
+
+// validator collects the ValidationErrors of a NachaFile while its records are visited in file order
+type validator struct {
+	record     int
+	recordType string
+	errs       ValidationErrors
+}
+
+// next moves the validator to the next record of the file
+func (v *validator) next(recordType string) {
+	v.record++
+	v.recordType = recordType
+}
+
+// add records a broken rule for a field of the current record
+func (v *validator) add(field string, rule string) {
+	v.errs = append(v.errs, &ValidationError{Record: v.record, RecordType: v.recordType, Field: field, Rule: rule})
+}
+
+// width checks that the value is exactly width characters long
+func (v *validator) width(field string, value string, width int) bool {
+	if len(value) != width {
+		v.add(field, fmt.Sprintf("must be %d characters, got %d", width, len(value)))
+		return false
+	}
+
+	return true
+}
+
+// required checks that the value is exactly width characters long and not blank
+func (v *validator) required(field string, value string, width int) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+		return
+	}
+
+	v.width(field, value, width)
+}
+
+// fixed checks that the value is equal to the expected fixed value
+func (v *validator) fixed(field string, value string, expected string) {
+	if value != expected {
+		v.add(field, fmt.Sprintf("must be %q", expected))
+	}
+}
+
+// oneOf checks that the value is one of the allowed values
+func (v *validator) oneOf(field string, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.add(field, "must be one of "+strings.Join(allowed, ", "))
+	}
+}
+
+// equal checks that a computed total stored in the field matches the expected value
+func (v *validator) equal(field string, value string, expected string, source string) {
+	if value != expected {
+		v.add(field, fmt.Sprintf("is %q but %s give %q", value, source, expected))
+	}
+}
+
+// numeric checks that the value is exactly width digits
+func (v *validator) numeric(field string, value string, width int) {
+	if v.width(field, value, width) && !isDigits(value) {
+		v.add(field, "must be numeric")
+	}
+}
+
+// numericOrBlank checks that the value only contains digits when it is not blank
+func (v *validator) numericOrBlank(field string, value string) {
+	if value != "" && !isDigits(value) {
+		v.add(field, "must be numeric")
+	}
+}
+
+// alphanumeric checks that the value is exactly width upper case letters or digits
+func (v *validator) alphanumeric(field string, value string, width int) {
+	if !v.width(field, value, width) {
+		return
+	}
+
+	for _, c := range value {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			v.add(field, "must be upper case letters or digits")
+			return
+		}
+	}
+}
+
+// date checks that the value is a valid date in the YYMMDD format
+func (v *validator) date(field string, value string) {
+	if _, err := time.Parse("060102", value); err != nil {
+		v.add(field, "must be a valid date in the YYMMDD format")
+	}
+}
+
+// time checks that the value is a valid time in the HHMM format
+func (v *validator) time(field string, value string) {
+	if _, err := time.Parse("1504", value); err != nil {
+		v.add(field, "must be a valid time in the HHMM format")
+	}
+}
+
+// isDigits reports whether the value is not empty and only contains digits
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
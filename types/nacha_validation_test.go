@@ -0,0 +1,163 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newValidTestFile returns a generated file with a debit batch of two entries, the second one with an addenda record
+func newValidTestFile(t *testing.T) *NachaFile {
+	t.Helper()
+
+	file := newTestFile(t)
+	batch := addTestBatch(t, file, "CCD", 225, 27, 27)
+	batch.Entries[1].NewAddenda().SetPaymentRelatedInformation("Invoice 42")
+	must(t, file.GenerateFile())
+	return file
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(f *NachaFile)
+		record int    // Record of the expected ValidationError, 0 for a valid file
+		field  string // Field of the expected ValidationError
+		rule   string // Part of the rule of the expected ValidationError
+	}{
+		{name: "valid file", modify: func(f *NachaFile) {}},
+		{
+			name:   "required field",
+			modify: func(f *NachaFile) { f.Batches[0].Header.CompanyName = strings.Repeat(" ", 16) },
+			record: 2, field: "CompanyName", rule: "is required",
+		},
+		{
+			name:   "field width",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[0].DFIAccountNumber = "29079117" },
+			record: 3, field: "DFIAccountNumber", rule: "must be 17 characters, got 8",
+		},
+		{
+			name:   "transaction code",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[0].TransactionCode = "25" },
+			record: 3, field: "TransactionCode", rule: "must be one of",
+		},
+		{
+			name:   "routing check digit",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[0].CheckDigit = "2" },
+			record: 3, field: "CheckDigit", rule: "must be 1 to match the ABA checksum",
+		},
+		{
+			name:   "addenda record indicator",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[1].AddendaRecordIndicator = "0" },
+			record: 4, field: "AddendaRecordIndicator", rule: "must be 1 when the entry has addenda records",
+		},
+		{
+			name:   "trace number ODFI",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[0].TraceNumber = "021000020000001" },
+			record: 3, field: "TraceNumber", rule: "must start with the batch ODFIIdentification",
+		},
+		{
+			name: "duplicate trace number",
+			modify: func(f *NachaFile) {
+				must(t, f.Batches[0].Entries[1].SetTraceNumber(testODFI, 1))
+			},
+			record: 4, field: "TraceNumber", rule: "duplicates the trace number of record 3",
+		},
+		{
+			name:   "addenda entry detail sequence number",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[1].Addenda[0].EntryDetailSequenceNumber = "0000009" },
+			record: 5, field: "EntryDetailSequenceNumber", rule: "must match the last 7 digits of the entry TraceNumber",
+		},
+		{
+			name:   "service class code",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[0].TransactionCode = "22" },
+			record: 3, field: "TransactionCode", rule: "credit entries are not allowed in debit only (225) batches",
+		},
+		{
+			name:   "batch entry addenda count",
+			modify: func(f *NachaFile) { f.Batches[0].Control.EntryAddendaCount = "000002" },
+			record: 6, field: "EntryAddendaCount", rule: "the entries and addenda of the batch give \"000003\"",
+		},
+		{
+			name:   "batch entry hash",
+			modify: func(f *NachaFile) { f.Batches[0].Control.EntryHash = "0000000001" },
+			record: 6, field: "EntryHash", rule: "the entries of the batch give \"0004200004\"",
+		},
+		{
+			name:   "batch total debits",
+			modify: func(f *NachaFile) { f.Batches[0].Control.TotalDebits = "000000000001" },
+			record: 6, field: "TotalDebits", rule: "the debit entries of the batch give \"000000020050\"",
+		},
+		{
+			name:   "batch total credits",
+			modify: func(f *NachaFile) { f.Batches[0].Control.TotalCredits = "000000000001" },
+			record: 6, field: "TotalCredits", rule: "the credit entries of the batch give \"000000000000\"",
+		},
+		{
+			name:   "batch number",
+			modify: func(f *NachaFile) { f.Batches[0].Control.BatchNumber = "0000002" },
+			record: 6, field: "BatchNumber", rule: "the batch header give \"0000001\"",
+		},
+		{
+			name:   "file batch count",
+			modify: func(f *NachaFile) { f.Control.BatchCount = "000002" },
+			record: 7, field: "BatchCount", rule: "the number of batches give \"000001\"",
+		},
+		{
+			name:   "file entry hash",
+			modify: func(f *NachaFile) { f.Control.EntryHash = "0000000001" },
+			record: 7, field: "EntryHash", rule: "the batch controls give \"0004200004\"",
+		},
+		{
+			name:   "file total debits",
+			modify: func(f *NachaFile) { f.Control.TotalDebits = "000000000001" },
+			record: 7, field: "TotalDebits", rule: "the batch controls give \"000000020050\"",
+		},
+		{
+			name:   "block count",
+			modify: func(f *NachaFile) { f.Control.BlockCount = "000002" },
+			record: 7, field: "BlockCount", rule: "the number of blocks give \"000001\"",
+		},
+		{
+			name:   "block fillers",
+			modify: func(f *NachaFile) { f.BlockFillers = f.BlockFillers[1:] },
+			record: 7, field: "BlockFillers", rule: "multiple of 10 records",
+		},
+		{
+			name:   "block filler content",
+			modify: func(f *NachaFile) { f.BlockFillers[0].Reserved = strings.Repeat("8", 94) },
+			record: 8, field: "Reserved", rule: "must be 94 characters of 9s",
+		},
+		{
+			name:   "prenote amount",
+			modify: func(f *NachaFile) { f.Batches[0].Entries[0].TransactionCode = "28" },
+			record: 3, field: "Amount", rule: "must be zero for prenote entries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newValidTestFile(t)
+			tt.modify(file)
+
+			err := file.Validate()
+			if tt.record == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			for _, e := range errs {
+				if e.Record == tt.record && e.Field == tt.field && strings.Contains(e.Rule, tt.rule) {
+					return
+				}
+			}
+			t.Errorf("Validate() error =\n%v\nwant record %d %s: %s", err, tt.record, tt.field, tt.rule)
+		})
+	}
+}